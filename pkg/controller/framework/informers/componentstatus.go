@@ -0,0 +1,121 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"reflect"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// ComponentStatusInformer is a type of SharedIndexInformer which watches and lists all component statuses.
+// ComponentStatus is cluster-scoped, so unlike the other core informers this one has no
+// per-namespace lister.
+type ComponentStatusInformer interface {
+	Informer() framework.SharedIndexInformer
+	Indexer() cache.Indexer
+	Lister() *ComponentStatusLister
+}
+
+type componentStatusInformer struct {
+	*sharedInformerFactory
+}
+
+// CreateSharedComponentStatusIndexInformer returns a SharedIndexInformer that lists and watches all component statuses
+func CreateSharedComponentStatusIndexInformer(client clientset.Interface, resyncPeriod time.Duration) framework.SharedIndexInformer {
+	sharedIndexInformer := framework.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+				return client.Core().ComponentStatuses().List(options)
+			},
+			WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+				return client.Core().ComponentStatuses().Watch(options)
+			},
+		},
+		&api.ComponentStatus{},
+		resyncPeriod,
+		cache.Indexers{})
+
+	return sharedIndexInformer
+}
+
+func (f *componentStatusInformer) Informer() framework.SharedIndexInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(&api.ComponentStatus{})
+	informer, exists := f.informers[informerType]
+	if exists {
+		return informer
+	}
+
+	lw := f.listerWatcher(api.Resource("componentstatuses"), &cache.ListWatch{
+		ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+			f.tweak(&options)
+			return f.client.Core().ComponentStatuses().List(options)
+		},
+		WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+			f.tweak(&options)
+			return f.client.Core().ComponentStatuses().Watch(options)
+		},
+	})
+	informer = framework.NewSharedIndexInformer(lw, &api.ComponentStatus{}, f.defaultResync, cache.Indexers{})
+	f.informers[informerType] = informer
+
+	return informer
+}
+
+func (f *componentStatusInformer) Indexer() cache.Indexer {
+	return f.Informer().GetIndexer()
+}
+
+func (f *componentStatusInformer) Lister() *ComponentStatusLister {
+	return &ComponentStatusLister{Indexer: f.Indexer()}
+}
+
+// ComponentStatusLister helps list component statuses from the shared informer's cache.
+type ComponentStatusLister struct {
+	Indexer cache.Indexer
+}
+
+// List lists all component statuses in the indexer matching selector.
+func (s *ComponentStatusLister) List(selector labels.Selector) (ret []*api.ComponentStatus, err error) {
+	err = cache.ListAll(s.Indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.ComponentStatus))
+	})
+	return ret, err
+}
+
+// Get retrieves the component status with the given name.
+func (s *ComponentStatusLister) Get(name string) (*api.ComponentStatus, error) {
+	obj, exists, err := s.Indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(api.Resource("componentstatus"), name)
+	}
+	return obj.(*api.ComponentStatus), nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"reflect"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// ConfigMapInformer is a type of SharedIndexInformer which watches and lists all config maps.
+// Interface provides constructor for informer and lister for config maps
+type ConfigMapInformer interface {
+	Informer() framework.SharedIndexInformer
+	Indexer() cache.Indexer
+	Lister() *ConfigMapLister
+}
+
+type configMapInformer struct {
+	*sharedInformerFactory
+}
+
+// CreateSharedConfigMapIndexInformer returns a SharedIndexInformer that lists and watches all config maps
+func CreateSharedConfigMapIndexInformer(client clientset.Interface, resyncPeriod time.Duration) framework.SharedIndexInformer {
+	sharedIndexInformer := framework.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+				return client.Core().ConfigMaps(api.NamespaceAll).List(options)
+			},
+			WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+				return client.Core().ConfigMaps(api.NamespaceAll).Watch(options)
+			},
+		},
+		&api.ConfigMap{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	return sharedIndexInformer
+}
+
+func (f *configMapInformer) Informer() framework.SharedIndexInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(&api.ConfigMap{})
+	informer, exists := f.informers[informerType]
+	if exists {
+		return informer
+	}
+	lw := f.listerWatcher(api.Resource("configmaps"), &cache.ListWatch{
+		ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+			f.tweak(&options)
+			return f.client.Core().ConfigMaps(f.namespace).List(options)
+		},
+		WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+			f.tweak(&options)
+			return f.client.Core().ConfigMaps(f.namespace).Watch(options)
+		},
+	})
+	informer = framework.NewSharedIndexInformer(lw, &api.ConfigMap{}, f.defaultResync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	f.informers[informerType] = informer
+
+	return informer
+}
+
+func (f *configMapInformer) Indexer() cache.Indexer {
+	return f.Informer().GetIndexer()
+}
+
+func (f *configMapInformer) Lister() *ConfigMapLister {
+	return &ConfigMapLister{Indexer: f.Indexer()}
+}
+
+// ConfigMapLister helps list config maps from the shared informer's cache.
+type ConfigMapLister struct {
+	Indexer cache.Indexer
+}
+
+// List lists all config maps in the indexer matching selector.
+func (s *ConfigMapLister) List(selector labels.Selector) (ret []*api.ConfigMap, err error) {
+	err = cache.ListAll(s.Indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.ConfigMap))
+	})
+	return ret, err
+}
+
+// ConfigMaps returns a lister scoped to namespace.
+func (s *ConfigMapLister) ConfigMaps(namespace string) configMapNamespaceLister {
+	return configMapNamespaceLister{indexer: s.Indexer, namespace: namespace}
+}
+
+type configMapNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all config maps in the indexer for a given namespace matching selector.
+func (s configMapNamespaceLister) List(selector labels.Selector) (ret []*api.ConfigMap, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.ConfigMap))
+	})
+	return ret, err
+}
+
+// Get retrieves the config map for a given namespace and name.
+func (s configMapNamespaceLister) Get(name string) (*api.ConfigMap, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(api.Resource("configmap"), name)
+	}
+	return obj.(*api.ConfigMap), nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"reflect"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/apis/batch"
+	"k8s.io/kubernetes/pkg/client/cache"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// CronJobInformer is a type of SharedIndexInformer which watches and lists all cron jobs.
+// Interface provides constructor for informer and lister for cron jobs
+type CronJobInformer interface {
+	Informer() framework.SharedIndexInformer
+	Indexer() cache.Indexer
+	Lister() *CronJobLister
+}
+
+type cronJobInformer struct {
+	*sharedInformerFactory
+}
+
+// CreateSharedCronJobIndexInformer returns a SharedIndexInformer that lists and watches all cron jobs
+func CreateSharedCronJobIndexInformer(client clientset.Interface, resyncPeriod time.Duration) framework.SharedIndexInformer {
+	sharedIndexInformer := framework.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+				return client.Batch().CronJobs(api.NamespaceAll).List(options)
+			},
+			WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+				return client.Batch().CronJobs(api.NamespaceAll).Watch(options)
+			},
+		},
+		&batch.CronJob{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	return sharedIndexInformer
+}
+
+func (f *cronJobInformer) Informer() framework.SharedIndexInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(&batch.CronJob{})
+	informer, exists := f.informers[informerType]
+	if exists {
+		return informer
+	}
+	lw := f.listerWatcher(batch.Resource("cronjobs"), &cache.ListWatch{
+		ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+			f.tweak(&options)
+			return f.client.Batch().CronJobs(f.namespace).List(options)
+		},
+		WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+			f.tweak(&options)
+			return f.client.Batch().CronJobs(f.namespace).Watch(options)
+		},
+	})
+	informer = framework.NewSharedIndexInformer(lw, &batch.CronJob{}, f.defaultResync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	f.informers[informerType] = informer
+
+	return informer
+}
+
+func (f *cronJobInformer) Indexer() cache.Indexer {
+	return f.Informer().GetIndexer()
+}
+
+func (f *cronJobInformer) Lister() *CronJobLister {
+	return &CronJobLister{Indexer: f.Indexer()}
+}
+
+// CronJobLister helps list cron jobs from the shared informer's cache.
+type CronJobLister struct {
+	Indexer cache.Indexer
+}
+
+// List lists all cron jobs in the indexer matching selector.
+func (s *CronJobLister) List(selector labels.Selector) (ret []*batch.CronJob, err error) {
+	err = cache.ListAll(s.Indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*batch.CronJob))
+	})
+	return ret, err
+}
+
+// CronJobs returns a lister scoped to namespace.
+func (s *CronJobLister) CronJobs(namespace string) cronJobNamespaceLister {
+	return cronJobNamespaceLister{indexer: s.Indexer, namespace: namespace}
+}
+
+type cronJobNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all cron jobs in the indexer for a given namespace matching selector.
+func (s cronJobNamespaceLister) List(selector labels.Selector) (ret []*batch.CronJob, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*batch.CronJob))
+	})
+	return ret, err
+}
+
+// Get retrieves the cron job for a given namespace and name.
+func (s cronJobNamespaceLister) Get(name string) (*batch.CronJob, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(batch.Resource("cronjob"), name)
+	}
+	return obj.(*batch.CronJob), nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"reflect"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// EndpointsInformer is a type of SharedIndexInformer which watches and lists all endpoints.
+// Interface provides constructor for informer and lister for endpoints
+type EndpointsInformer interface {
+	Informer() framework.SharedIndexInformer
+	Indexer() cache.Indexer
+	Lister() *EndpointsLister
+}
+
+type endpointsInformer struct {
+	*sharedInformerFactory
+}
+
+// CreateSharedEndpointsIndexInformer returns a SharedIndexInformer that lists and watches all endpoints
+func CreateSharedEndpointsIndexInformer(client clientset.Interface, resyncPeriod time.Duration) framework.SharedIndexInformer {
+	sharedIndexInformer := framework.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+				return client.Core().Endpoints(api.NamespaceAll).List(options)
+			},
+			WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+				return client.Core().Endpoints(api.NamespaceAll).Watch(options)
+			},
+		},
+		&api.Endpoints{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	return sharedIndexInformer
+}
+
+func (f *endpointsInformer) Informer() framework.SharedIndexInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(&api.Endpoints{})
+	informer, exists := f.informers[informerType]
+	if exists {
+		return informer
+	}
+	lw := f.listerWatcher(api.Resource("endpoints"), &cache.ListWatch{
+		ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+			f.tweak(&options)
+			return f.client.Core().Endpoints(f.namespace).List(options)
+		},
+		WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+			f.tweak(&options)
+			return f.client.Core().Endpoints(f.namespace).Watch(options)
+		},
+	})
+	informer = framework.NewSharedIndexInformer(lw, &api.Endpoints{}, f.defaultResync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	f.informers[informerType] = informer
+
+	return informer
+}
+
+func (f *endpointsInformer) Indexer() cache.Indexer {
+	return f.Informer().GetIndexer()
+}
+
+func (f *endpointsInformer) Lister() *EndpointsLister {
+	return &EndpointsLister{Indexer: f.Indexer()}
+}
+
+// EndpointsLister helps list endpoints from the shared informer's cache.
+type EndpointsLister struct {
+	Indexer cache.Indexer
+}
+
+// List lists all endpoints in the indexer matching selector.
+func (s *EndpointsLister) List(selector labels.Selector) (ret []*api.Endpoints, err error) {
+	err = cache.ListAll(s.Indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.Endpoints))
+	})
+	return ret, err
+}
+
+// Endpoints returns a lister scoped to namespace.
+func (s *EndpointsLister) Endpoints(namespace string) endpointsNamespaceLister {
+	return endpointsNamespaceLister{indexer: s.Indexer, namespace: namespace}
+}
+
+type endpointsNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all endpoints in the indexer for a given namespace matching selector.
+func (s endpointsNamespaceLister) List(selector labels.Selector) (ret []*api.Endpoints, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.Endpoints))
+	})
+	return ret, err
+}
+
+// Get retrieves the endpoints for a given namespace and name.
+func (s endpointsNamespaceLister) Get(name string) (*api.Endpoints, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(api.Resource("endpoints"), name)
+	}
+	return obj.(*api.Endpoints), nil
+}
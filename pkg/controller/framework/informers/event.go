@@ -0,0 +1,138 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"reflect"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// EventInformer is a type of SharedIndexInformer which watches and lists all events.
+// Interface provides constructor for informer and lister for events
+type EventInformer interface {
+	Informer() framework.SharedIndexInformer
+	Indexer() cache.Indexer
+	Lister() *EventLister
+}
+
+type eventInformer struct {
+	*sharedInformerFactory
+}
+
+// CreateSharedEventIndexInformer returns a SharedIndexInformer that lists and watches all events
+func CreateSharedEventIndexInformer(client clientset.Interface, resyncPeriod time.Duration) framework.SharedIndexInformer {
+	sharedIndexInformer := framework.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+				return client.Core().Events(api.NamespaceAll).List(options)
+			},
+			WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+				return client.Core().Events(api.NamespaceAll).Watch(options)
+			},
+		},
+		&api.Event{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	return sharedIndexInformer
+}
+
+func (f *eventInformer) Informer() framework.SharedIndexInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(&api.Event{})
+	informer, exists := f.informers[informerType]
+	if exists {
+		return informer
+	}
+	lw := f.listerWatcher(api.Resource("events"), &cache.ListWatch{
+		ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+			f.tweak(&options)
+			return f.client.Core().Events(f.namespace).List(options)
+		},
+		WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+			f.tweak(&options)
+			return f.client.Core().Events(f.namespace).Watch(options)
+		},
+	})
+	informer = framework.NewSharedIndexInformer(lw, &api.Event{}, f.defaultResync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	f.informers[informerType] = informer
+
+	return informer
+}
+
+func (f *eventInformer) Indexer() cache.Indexer {
+	return f.Informer().GetIndexer()
+}
+
+func (f *eventInformer) Lister() *EventLister {
+	return &EventLister{Indexer: f.Indexer()}
+}
+
+// EventLister helps list events from the shared informer's cache.
+type EventLister struct {
+	Indexer cache.Indexer
+}
+
+// List lists all events in the indexer matching selector.
+func (s *EventLister) List(selector labels.Selector) (ret []*api.Event, err error) {
+	err = cache.ListAll(s.Indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.Event))
+	})
+	return ret, err
+}
+
+// Events returns a lister scoped to namespace.
+func (s *EventLister) Events(namespace string) eventNamespaceLister {
+	return eventNamespaceLister{indexer: s.Indexer, namespace: namespace}
+}
+
+type eventNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all events in the indexer for a given namespace matching selector.
+func (s eventNamespaceLister) List(selector labels.Selector) (ret []*api.Event, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.Event))
+	})
+	return ret, err
+}
+
+// Get retrieves the event for a given namespace and name.
+func (s eventNamespaceLister) Get(name string) (*api.Event, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(api.Resource("event"), name)
+	}
+	return obj.(*api.Event), nil
+}
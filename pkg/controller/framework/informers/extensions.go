@@ -0,0 +1,40 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+// ExtensionsInterface groups the informers for the extensions API group.
+type ExtensionsInterface interface {
+	Deployments() DeploymentInformer
+	DaemonSets() DaemonSetInformer
+	ReplicaSets() ReplicaSetInformer
+}
+
+type extensionsGroup struct {
+	*sharedInformerFactory
+}
+
+func (g *extensionsGroup) Deployments() DeploymentInformer {
+	return &deploymentInformer{sharedInformerFactory: g.sharedInformerFactory}
+}
+
+func (g *extensionsGroup) DaemonSets() DaemonSetInformer {
+	return &daemonSetInformer{sharedInformerFactory: g.sharedInformerFactory}
+}
+
+func (g *extensionsGroup) ReplicaSets() ReplicaSetInformer {
+	return &replicaSetInformer{sharedInformerFactory: g.sharedInformerFactory}
+}
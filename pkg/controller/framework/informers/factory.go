@@ -22,24 +22,57 @@ import (
 	"time"
 
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/client/cache"
 	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/client/typed/dynamic"
 	"k8s.io/kubernetes/pkg/controller/framework"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/watch"
 )
 
-// SharedInformerFactory provides interface which holds unique informers for pods, nodes, namespaces, persistent volume
-// claims and persistent volumes
+// SharedInformerFactory provides interface which holds unique informers for the core resources --
+// pods, nodes, namespaces, persistent volume claims, persistent volumes, config maps, secrets,
+// services, endpoints, events, service accounts, limit ranges, resource quotas, replication
+// controllers, pod templates and component statuses -- plus grouped access to the apps,
+// extensions and batch API groups.
 type SharedInformerFactory interface {
-	// Start starts informers that can start AFTER the API server and controllers have started
+	// Start starts informers that can start AFTER the API server and controllers have started.
+	// Informers already started by a prior call are left running, so Start is safe to call
+	// repeatedly as additional controllers register their informers on the same factory.
 	Start(stopCh <-chan struct{})
 
+	// WaitForCacheSync blocks until all informers started so far have synced, or stopCh is
+	// closed. It returns, for each started informer's type, whether its cache is synced.
+	// Informers obtained from ForResource's dynamic-client fallback aren't covered by this
+	// map; wait on their own HasSynced via cache.WaitForCacheSync instead.
+	WaitForCacheSync(stopCh <-chan struct{}) map[reflect.Type]bool
+
 	Pods() PodInformer
 	Nodes() NodeInformer
 	Namespaces() NamespaceInformer
 	PersistentVolumeClaims() PVCInformer
 	PersistentVolumes() PVInformer
+	ConfigMaps() ConfigMapInformer
+	Secrets() SecretInformer
+	Services() ServiceInformer
+	Endpoints() EndpointsInformer
+	Events() EventInformer
+	ServiceAccounts() ServiceAccountInformer
+	LimitRanges() LimitRangeInformer
+	ResourceQuotas() ResourceQuotaInformer
+	ReplicationControllers() ReplicationControllerInformer
+	PodTemplates() PodTemplateInformer
+	ComponentStatuses() ComponentStatusInformer
+
+	Apps() AppsInterface
+	Extensions() ExtensionsInterface
+	Batch() BatchInterface
+
+	// ForResource returns the GenericInformer for resource, dispatching to one
+	// of the typed informers above when resource is a built-in type, and to a
+	// dynamic-client-backed informer otherwise, e.g. for a custom resource.
+	ForResource(resource unversioned.GroupVersionResource) (GenericInformer, error)
 }
 
 type sharedInformerFactory struct {
@@ -47,27 +80,166 @@ type sharedInformerFactory struct {
 	lock          sync.Mutex
 	defaultResync time.Duration
 	informers     map[reflect.Type]framework.SharedIndexInformer
+	// startedInformers tracks which informers have already been started by Start,
+	// so repeated Start calls on the same factory don't relaunch their Run goroutines.
+	startedInformers map[reflect.Type]bool
+	// customListerWatchers lets callers override the ListerWatcher used for a given
+	// resource, e.g. to inject a fake ListWatch in a unit test or to proxy through a
+	// cache, without replacing the whole clientset.
+	customListerWatchers map[unversioned.GroupResource]cache.ListerWatcher
+	// tweakListOptions, if set, is applied to every api.ListOptions this factory's
+	// informers use to List/Watch, so a single factory can be scoped to a label or
+	// field selector.
+	tweakListOptions func(*api.ListOptions)
+	// namespace restricts every namespaced informer this factory creates to a single
+	// namespace. It is api.NamespaceAll unless the factory was built with
+	// NewFilteredSharedInformerFactory.
+	namespace string
+	// dynamicClientPool is used by ForResource to build an informer for a GroupVersionResource
+	// that isn't one of the factory's generated, typed informers, e.g. a custom resource. It is
+	// nil unless the factory was built with WithDynamicClientPool.
+	dynamicClientPool dynamic.ClientPool
+	// genericInformers holds the dynamic-client-backed informers ForResource falls back to,
+	// keyed by GroupVersionResource since they all share the *unstructured.Unstructured type
+	// and so can't be keyed by reflect.Type like informers above.
+	genericInformers map[unversioned.GroupVersionResource]framework.SharedIndexInformer
+	// startedGenericInformers mirrors startedInformers for genericInformers.
+	startedGenericInformers map[unversioned.GroupVersionResource]bool
+}
+
+// SharedInformerOption configures a SharedInformerFactory constructed via
+// NewSharedInformerFactoryWithOptions.
+type SharedInformerOption func(*sharedInformerFactory)
+
+// WithCustomListerWatcher overrides the ListerWatcher the factory uses for gr, instead of
+// the default one built from the factory's clientset.
+func WithCustomListerWatcher(gr unversioned.GroupResource, lw cache.ListerWatcher) SharedInformerOption {
+	return func(f *sharedInformerFactory) {
+		f.customListerWatchers[gr] = lw
+	}
+}
+
+// WithTweakListOptions sets a function that is called on the api.ListOptions used by
+// every List and Watch this factory's informers issue, e.g. to scope them to a label
+// or field selector.
+func WithTweakListOptions(tweak func(*api.ListOptions)) SharedInformerOption {
+	return func(f *sharedInformerFactory) {
+		f.tweakListOptions = tweak
+	}
 }
 
 // NewSharedInformerFactory constructs a new instance of sharedInformerFactory
 func NewSharedInformerFactory(client clientset.Interface, defaultResync time.Duration) SharedInformerFactory {
-	return &sharedInformerFactory{
-		client:        client,
-		defaultResync: defaultResync,
-		informers:     make(map[reflect.Type]framework.SharedIndexInformer),
+	return NewSharedInformerFactoryWithOptions(client, defaultResync)
+}
+
+// NewFilteredSharedInformerFactory constructs a new instance of sharedInformerFactory whose
+// namespaced informers only see namespace (api.NamespaceAll for every namespace, as
+// NewSharedInformerFactory uses), and whose List/Watch calls are tweaked by
+// tweakListOptions. It is a convenience wrapper around
+// NewSharedInformerFactoryWithOptions for the common case of scoping a factory to one
+// namespace, e.g. for a multi-tenant controller or an RBAC-limited service account.
+func NewFilteredSharedInformerFactory(client clientset.Interface, defaultResync time.Duration, namespace string, tweakListOptions func(*api.ListOptions)) SharedInformerFactory {
+	return NewSharedInformerFactoryWithOptions(client, defaultResync, WithNamespace(namespace), WithTweakListOptions(tweakListOptions))
+}
+
+// WithNamespace restricts the factory's namespaced informers to namespace.
+func WithNamespace(namespace string) SharedInformerOption {
+	return func(f *sharedInformerFactory) {
+		f.namespace = namespace
 	}
 }
 
-// Start initializes all requested informers.
+// WithDynamicClientPool sets the dynamic client pool ForResource uses to build
+// an informer for a resource that isn't one of the factory's generated, typed
+// informers, e.g. a CustomResourceDefinition. Without this option, ForResource
+// returns an error for any such resource.
+func WithDynamicClientPool(pool dynamic.ClientPool) SharedInformerOption {
+	return func(f *sharedInformerFactory) {
+		f.dynamicClientPool = pool
+	}
+}
+
+// NewSharedInformerFactoryWithOptions constructs a new instance of sharedInformerFactory,
+// applying the given options on top of the defaults NewSharedInformerFactory uses.
+func NewSharedInformerFactoryWithOptions(client clientset.Interface, defaultResync time.Duration, options ...SharedInformerOption) SharedInformerFactory {
+	factory := &sharedInformerFactory{
+		client:                  client,
+		defaultResync:           defaultResync,
+		informers:               make(map[reflect.Type]framework.SharedIndexInformer),
+		startedInformers:        make(map[reflect.Type]bool),
+		customListerWatchers:    make(map[unversioned.GroupResource]cache.ListerWatcher),
+		namespace:               api.NamespaceAll,
+		genericInformers:        make(map[unversioned.GroupVersionResource]framework.SharedIndexInformer),
+		startedGenericInformers: make(map[unversioned.GroupVersionResource]bool),
+	}
+	for _, opt := range options {
+		opt(factory)
+	}
+	return factory
+}
+
+// listerWatcher returns the ListerWatcher registered for gr via WithCustomListerWatcher,
+// or def if none was registered. Callers already hold f.lock (they call this from within
+// their own Informer() method, which locks to check f.informers), so this must not lock
+// again — sync.Mutex isn't reentrant.
+func (f *sharedInformerFactory) listerWatcher(gr unversioned.GroupResource, def cache.ListerWatcher) cache.ListerWatcher {
+	if lw, ok := f.customListerWatchers[gr]; ok {
+		return lw
+	}
+	return def
+}
+
+// tweak applies the factory's WithTweakListOptions callback, if any, to options.
+func (f *sharedInformerFactory) tweak(options *api.ListOptions) {
+	if f.tweakListOptions != nil {
+		f.tweakListOptions(options)
+	}
+}
+
+// Start initializes all requested informers that have not already been started by a prior
+// call. It is safe to call more than once, e.g. as each controller registers its informers
+// on a shared factory before the factory is started.
 func (s *sharedInformerFactory) Start(stopCh <-chan struct{}) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	for _, informer := range s.informers {
-		go informer.Run(stopCh)
+	for informerType, informer := range s.informers {
+		if !s.startedInformers[informerType] {
+			go informer.Run(stopCh)
+			s.startedInformers[informerType] = true
+		}
+	}
+	for resource, informer := range s.genericInformers {
+		if !s.startedGenericInformers[resource] {
+			go informer.Run(stopCh)
+			s.startedGenericInformers[resource] = true
+		}
 	}
 }
 
+// WaitForCacheSync waits for all started informers' caches to be synced.
+func (s *sharedInformerFactory) WaitForCacheSync(stopCh <-chan struct{}) map[reflect.Type]bool {
+	informers := func() map[reflect.Type]framework.SharedIndexInformer {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		informers := map[reflect.Type]framework.SharedIndexInformer{}
+		for informerType, informer := range s.informers {
+			if s.startedInformers[informerType] {
+				informers[informerType] = informer
+			}
+		}
+		return informers
+	}()
+
+	res := map[reflect.Type]bool{}
+	for informType, informer := range informers {
+		res[informType] = cache.WaitForCacheSync(stopCh, informer.HasSynced)
+	}
+	return res
+}
+
 // Pods returns a SharedIndexInformer that lists and watches all pods
 func (f *sharedInformerFactory) Pods() PodInformer {
 	return &podInformer{sharedInformerFactory: f}
@@ -93,6 +265,76 @@ func (f *sharedInformerFactory) PersistentVolumes() PVInformer {
 	return &pvInformer{sharedInformerFactory: f}
 }
 
+// ConfigMaps returns a SharedIndexInformer that lists and watches all config maps
+func (f *sharedInformerFactory) ConfigMaps() ConfigMapInformer {
+	return &configMapInformer{sharedInformerFactory: f}
+}
+
+// Secrets returns a SharedIndexInformer that lists and watches all secrets
+func (f *sharedInformerFactory) Secrets() SecretInformer {
+	return &secretInformer{sharedInformerFactory: f}
+}
+
+// Services returns a SharedIndexInformer that lists and watches all services
+func (f *sharedInformerFactory) Services() ServiceInformer {
+	return &serviceInformer{sharedInformerFactory: f}
+}
+
+// Endpoints returns a SharedIndexInformer that lists and watches all endpoints
+func (f *sharedInformerFactory) Endpoints() EndpointsInformer {
+	return &endpointsInformer{sharedInformerFactory: f}
+}
+
+// Events returns a SharedIndexInformer that lists and watches all events
+func (f *sharedInformerFactory) Events() EventInformer {
+	return &eventInformer{sharedInformerFactory: f}
+}
+
+// ServiceAccounts returns a SharedIndexInformer that lists and watches all service accounts
+func (f *sharedInformerFactory) ServiceAccounts() ServiceAccountInformer {
+	return &serviceAccountInformer{sharedInformerFactory: f}
+}
+
+// LimitRanges returns a SharedIndexInformer that lists and watches all limit ranges
+func (f *sharedInformerFactory) LimitRanges() LimitRangeInformer {
+	return &limitRangeInformer{sharedInformerFactory: f}
+}
+
+// ResourceQuotas returns a SharedIndexInformer that lists and watches all resource quotas
+func (f *sharedInformerFactory) ResourceQuotas() ResourceQuotaInformer {
+	return &resourceQuotaInformer{sharedInformerFactory: f}
+}
+
+// ReplicationControllers returns a SharedIndexInformer that lists and watches all replication controllers
+func (f *sharedInformerFactory) ReplicationControllers() ReplicationControllerInformer {
+	return &replicationControllerInformer{sharedInformerFactory: f}
+}
+
+// PodTemplates returns a SharedIndexInformer that lists and watches all pod templates
+func (f *sharedInformerFactory) PodTemplates() PodTemplateInformer {
+	return &podTemplateInformer{sharedInformerFactory: f}
+}
+
+// ComponentStatuses returns a SharedIndexInformer that lists and watches all component statuses
+func (f *sharedInformerFactory) ComponentStatuses() ComponentStatusInformer {
+	return &componentStatusInformer{sharedInformerFactory: f}
+}
+
+// Apps returns the grouped informers for the apps API group
+func (f *sharedInformerFactory) Apps() AppsInterface {
+	return &appsGroup{sharedInformerFactory: f}
+}
+
+// Extensions returns the grouped informers for the extensions API group
+func (f *sharedInformerFactory) Extensions() ExtensionsInterface {
+	return &extensionsGroup{sharedInformerFactory: f}
+}
+
+// Batch returns the grouped informers for the batch API group
+func (f *sharedInformerFactory) Batch() BatchInterface {
+	return &batchGroup{sharedInformerFactory: f}
+}
+
 // CreateSharedPodInformer returns a SharedIndexInformer that lists and watches all pods
 func CreateSharedPodInformer(client clientset.Interface, resyncPeriod time.Duration) framework.SharedIndexInformer {
 	sharedInformer := framework.NewSharedIndexInformer(
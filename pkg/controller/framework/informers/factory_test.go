@@ -0,0 +1,54 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSharedInformerFactoryInformerDoesNotDeadlock guards against
+// listerWatcher re-acquiring f.lock, which every XxxInformer().Informer()
+// method already holds when it calls listerWatcher.
+func TestSharedInformerFactoryInformerDoesNotDeadlock(t *testing.T) {
+	f := NewSharedInformerFactory(nil, 0)
+
+	done := make(chan struct{})
+	go func() {
+		f.Pods().Informer()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Pods().Informer() did not return; listerWatcher must not re-lock the factory mutex")
+	}
+}
+
+// TestSharedInformerFactoryCachesInformerByType verifies that requesting the
+// same resource's informer twice returns the one shared instance rather than
+// building a second one.
+func TestSharedInformerFactoryCachesInformerByType(t *testing.T) {
+	f := NewSharedInformerFactory(nil, 0)
+
+	first := f.Pods().Informer()
+	second := f.Pods().Informer()
+	if first != second {
+		t.Fatal("Pods().Informer() returned a different informer on the second call")
+	}
+}
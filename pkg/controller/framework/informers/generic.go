@@ -0,0 +1,217 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/apis/apps"
+	"k8s.io/kubernetes/pkg/apis/batch"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+	"k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/unstructured"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// GenericInformer is a SharedIndexInformer wrapper addressed by
+// unversioned.GroupVersionResource rather than Go type, so that a caller can
+// be handed an informer for a resource it only knows by name, including a
+// custom resource the factory has no generated types for.
+type GenericInformer interface {
+	Informer() framework.SharedIndexInformer
+	Lister() GenericLister
+}
+
+// GenericLister lists and gets resources by name, returning runtime.Object
+// so the same lister works whether the underlying informer is indexing a
+// typed built-in object or an *unstructured.Unstructured custom resource.
+type GenericLister interface {
+	List(selector labels.Selector) (ret []runtime.Object, err error)
+	ByNamespace(namespace string) GenericNamespaceLister
+}
+
+// GenericNamespaceLister lists and gets resources from a single namespace.
+type GenericNamespaceLister interface {
+	List(selector labels.Selector) (ret []runtime.Object, err error)
+	Get(name string) (runtime.Object, error)
+}
+
+type genericInformer struct {
+	informer framework.SharedIndexInformer
+	resource unversioned.GroupResource
+}
+
+func (i *genericInformer) Informer() framework.SharedIndexInformer {
+	return i.informer
+}
+
+func (i *genericInformer) Lister() GenericLister {
+	return NewGenericLister(i.informer.GetIndexer(), i.resource)
+}
+
+// NewGenericLister builds a GenericLister for resource backed by indexer.
+func NewGenericLister(indexer cache.Indexer, resource unversioned.GroupResource) GenericLister {
+	return &genericLister{indexer: indexer, resource: resource}
+}
+
+type genericLister struct {
+	indexer  cache.Indexer
+	resource unversioned.GroupResource
+}
+
+func (s *genericLister) List(selector labels.Selector) (ret []runtime.Object, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(runtime.Object))
+	})
+	return ret, err
+}
+
+func (s *genericLister) ByNamespace(namespace string) GenericNamespaceLister {
+	return &genericNamespaceLister{indexer: s.indexer, namespace: namespace, resource: s.resource}
+}
+
+type genericNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+	resource  unversioned.GroupResource
+}
+
+func (s *genericNamespaceLister) List(selector labels.Selector) (ret []runtime.Object, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(runtime.Object))
+	})
+	return ret, err
+}
+
+func (s *genericNamespaceLister) Get(name string) (runtime.Object, error) {
+	key := name
+	if s.namespace != "" {
+		key = s.namespace + "/" + name
+	}
+	obj, exists, err := s.indexer.GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(s.resource, name)
+	}
+	return obj.(runtime.Object), nil
+}
+
+// ForResource returns the GenericInformer for resource, reusing one of the
+// factory's typed informers when resource names a built-in type so the
+// cache stays shared, and otherwise falling back to a dynamic-client-backed
+// informer of *unstructured.Unstructured objects, e.g. for a custom
+// resource the factory has no generated client for.
+func (f *sharedInformerFactory) ForResource(resource unversioned.GroupVersionResource) (GenericInformer, error) {
+	gr := resource.GroupResource()
+
+	switch gr {
+	case api.Resource("pods"):
+		return &genericInformer{resource: gr, informer: f.Pods().Informer()}, nil
+	case api.Resource("nodes"):
+		return &genericInformer{resource: gr, informer: f.Nodes().Informer()}, nil
+	case api.Resource("namespaces"):
+		return &genericInformer{resource: gr, informer: f.Namespaces().Informer()}, nil
+	case api.Resource("persistentvolumeclaims"):
+		return &genericInformer{resource: gr, informer: f.PersistentVolumeClaims().Informer()}, nil
+	case api.Resource("persistentvolumes"):
+		return &genericInformer{resource: gr, informer: f.PersistentVolumes().Informer()}, nil
+	case api.Resource("configmaps"):
+		return &genericInformer{resource: gr, informer: f.ConfigMaps().Informer()}, nil
+	case api.Resource("secrets"):
+		return &genericInformer{resource: gr, informer: f.Secrets().Informer()}, nil
+	case api.Resource("services"):
+		return &genericInformer{resource: gr, informer: f.Services().Informer()}, nil
+	case api.Resource("endpoints"):
+		return &genericInformer{resource: gr, informer: f.Endpoints().Informer()}, nil
+	case api.Resource("events"):
+		return &genericInformer{resource: gr, informer: f.Events().Informer()}, nil
+	case api.Resource("serviceaccounts"):
+		return &genericInformer{resource: gr, informer: f.ServiceAccounts().Informer()}, nil
+	case api.Resource("limitranges"):
+		return &genericInformer{resource: gr, informer: f.LimitRanges().Informer()}, nil
+	case api.Resource("resourcequotas"):
+		return &genericInformer{resource: gr, informer: f.ResourceQuotas().Informer()}, nil
+	case api.Resource("replicationcontrollers"):
+		return &genericInformer{resource: gr, informer: f.ReplicationControllers().Informer()}, nil
+	case api.Resource("podtemplates"):
+		return &genericInformer{resource: gr, informer: f.PodTemplates().Informer()}, nil
+	case api.Resource("componentstatuses"):
+		return &genericInformer{resource: gr, informer: f.ComponentStatuses().Informer()}, nil
+	case extensions.Resource("deployments"):
+		return &genericInformer{resource: gr, informer: f.Extensions().Deployments().Informer()}, nil
+	case extensions.Resource("daemonsets"):
+		return &genericInformer{resource: gr, informer: f.Extensions().DaemonSets().Informer()}, nil
+	case extensions.Resource("replicasets"):
+		return &genericInformer{resource: gr, informer: f.Extensions().ReplicaSets().Informer()}, nil
+	case apps.Resource("statefulsets"):
+		return &genericInformer{resource: gr, informer: f.Apps().StatefulSets().Informer()}, nil
+	case batch.Resource("jobs"):
+		return &genericInformer{resource: gr, informer: f.Batch().Jobs().Informer()}, nil
+	case batch.Resource("cronjobs"):
+		return &genericInformer{resource: gr, informer: f.Batch().CronJobs().Informer()}, nil
+	}
+
+	return f.forDynamicResource(resource)
+}
+
+// forDynamicResource builds (or reuses) a SharedIndexInformer over resource
+// using the factory's dynamic client pool. It is the fallback ForResource
+// takes for any GroupVersionResource that isn't one of the factory's
+// generated, typed informers, e.g. a CustomResourceDefinition.
+func (f *sharedInformerFactory) forDynamicResource(resource unversioned.GroupVersionResource) (GenericInformer, error) {
+	if f.dynamicClientPool == nil {
+		return nil, fmt.Errorf("no informer found for %v, and no dynamic client pool configured to watch it as a custom resource", resource)
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informer, exists := f.genericInformers[resource]
+	if !exists {
+		client, err := f.dynamicClientPool.ClientForGroupVersionResource(resource)
+		if err != nil {
+			return nil, err
+		}
+		resourceClient := client.Resource(&unversioned.APIResource{Name: resource.Resource, Namespaced: true}, f.namespace)
+
+		informer = framework.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+					f.tweak(&options)
+					return resourceClient.List(&options)
+				},
+				WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+					f.tweak(&options)
+					return resourceClient.Watch(&options)
+				},
+			},
+			&unstructured.Unstructured{},
+			f.defaultResync,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		f.genericInformers[resource] = informer
+	}
+
+	return &genericInformer{resource: resource.GroupResource(), informer: informer}, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"reflect"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// PodTemplateInformer is a type of SharedIndexInformer which watches and lists all pod templates.
+// Interface provides constructor for informer and lister for pod templates
+type PodTemplateInformer interface {
+	Informer() framework.SharedIndexInformer
+	Indexer() cache.Indexer
+	Lister() *PodTemplateLister
+}
+
+type podTemplateInformer struct {
+	*sharedInformerFactory
+}
+
+// CreateSharedPodTemplateIndexInformer returns a SharedIndexInformer that lists and watches all pod templates
+func CreateSharedPodTemplateIndexInformer(client clientset.Interface, resyncPeriod time.Duration) framework.SharedIndexInformer {
+	sharedIndexInformer := framework.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+				return client.Core().PodTemplates(api.NamespaceAll).List(options)
+			},
+			WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+				return client.Core().PodTemplates(api.NamespaceAll).Watch(options)
+			},
+		},
+		&api.PodTemplate{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	return sharedIndexInformer
+}
+
+func (f *podTemplateInformer) Informer() framework.SharedIndexInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(&api.PodTemplate{})
+	informer, exists := f.informers[informerType]
+	if exists {
+		return informer
+	}
+	lw := f.listerWatcher(api.Resource("podtemplates"), &cache.ListWatch{
+		ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+			f.tweak(&options)
+			return f.client.Core().PodTemplates(f.namespace).List(options)
+		},
+		WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+			f.tweak(&options)
+			return f.client.Core().PodTemplates(f.namespace).Watch(options)
+		},
+	})
+	informer = framework.NewSharedIndexInformer(lw, &api.PodTemplate{}, f.defaultResync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	f.informers[informerType] = informer
+
+	return informer
+}
+
+func (f *podTemplateInformer) Indexer() cache.Indexer {
+	return f.Informer().GetIndexer()
+}
+
+func (f *podTemplateInformer) Lister() *PodTemplateLister {
+	return &PodTemplateLister{Indexer: f.Indexer()}
+}
+
+// PodTemplateLister helps list pod templates from the shared informer's cache.
+type PodTemplateLister struct {
+	Indexer cache.Indexer
+}
+
+// List lists all pod templates in the indexer matching selector.
+func (s *PodTemplateLister) List(selector labels.Selector) (ret []*api.PodTemplate, err error) {
+	err = cache.ListAll(s.Indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.PodTemplate))
+	})
+	return ret, err
+}
+
+// PodTemplates returns a lister scoped to namespace.
+func (s *PodTemplateLister) PodTemplates(namespace string) podTemplateNamespaceLister {
+	return podTemplateNamespaceLister{indexer: s.Indexer, namespace: namespace}
+}
+
+type podTemplateNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all pod templates in the indexer for a given namespace matching selector.
+func (s podTemplateNamespaceLister) List(selector labels.Selector) (ret []*api.PodTemplate, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.PodTemplate))
+	})
+	return ret, err
+}
+
+// Get retrieves the pod template for a given namespace and name.
+func (s podTemplateNamespaceLister) Get(name string) (*api.PodTemplate, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(api.Resource("podtemplate"), name)
+	}
+	return obj.(*api.PodTemplate), nil
+}
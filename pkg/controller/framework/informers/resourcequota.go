@@ -0,0 +1,138 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"reflect"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// ResourceQuotaInformer is a type of SharedIndexInformer which watches and lists all resource quotas.
+// Interface provides constructor for informer and lister for resource quotas
+type ResourceQuotaInformer interface {
+	Informer() framework.SharedIndexInformer
+	Indexer() cache.Indexer
+	Lister() *ResourceQuotaLister
+}
+
+type resourceQuotaInformer struct {
+	*sharedInformerFactory
+}
+
+// CreateSharedResourceQuotaIndexInformer returns a SharedIndexInformer that lists and watches all resource quotas
+func CreateSharedResourceQuotaIndexInformer(client clientset.Interface, resyncPeriod time.Duration) framework.SharedIndexInformer {
+	sharedIndexInformer := framework.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+				return client.Core().ResourceQuotas(api.NamespaceAll).List(options)
+			},
+			WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+				return client.Core().ResourceQuotas(api.NamespaceAll).Watch(options)
+			},
+		},
+		&api.ResourceQuota{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	return sharedIndexInformer
+}
+
+func (f *resourceQuotaInformer) Informer() framework.SharedIndexInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(&api.ResourceQuota{})
+	informer, exists := f.informers[informerType]
+	if exists {
+		return informer
+	}
+	lw := f.listerWatcher(api.Resource("resourcequotas"), &cache.ListWatch{
+		ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+			f.tweak(&options)
+			return f.client.Core().ResourceQuotas(f.namespace).List(options)
+		},
+		WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+			f.tweak(&options)
+			return f.client.Core().ResourceQuotas(f.namespace).Watch(options)
+		},
+	})
+	informer = framework.NewSharedIndexInformer(lw, &api.ResourceQuota{}, f.defaultResync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	f.informers[informerType] = informer
+
+	return informer
+}
+
+func (f *resourceQuotaInformer) Indexer() cache.Indexer {
+	return f.Informer().GetIndexer()
+}
+
+func (f *resourceQuotaInformer) Lister() *ResourceQuotaLister {
+	return &ResourceQuotaLister{Indexer: f.Indexer()}
+}
+
+// ResourceQuotaLister helps list resource quotas from the shared informer's cache.
+type ResourceQuotaLister struct {
+	Indexer cache.Indexer
+}
+
+// List lists all resource quotas in the indexer matching selector.
+func (s *ResourceQuotaLister) List(selector labels.Selector) (ret []*api.ResourceQuota, err error) {
+	err = cache.ListAll(s.Indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.ResourceQuota))
+	})
+	return ret, err
+}
+
+// ResourceQuotas returns a lister scoped to namespace.
+func (s *ResourceQuotaLister) ResourceQuotas(namespace string) resourceQuotaNamespaceLister {
+	return resourceQuotaNamespaceLister{indexer: s.Indexer, namespace: namespace}
+}
+
+type resourceQuotaNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all resource quotas in the indexer for a given namespace matching selector.
+func (s resourceQuotaNamespaceLister) List(selector labels.Selector) (ret []*api.ResourceQuota, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.ResourceQuota))
+	})
+	return ret, err
+}
+
+// Get retrieves the resource quota for a given namespace and name.
+func (s resourceQuotaNamespaceLister) Get(name string) (*api.ResourceQuota, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(api.Resource("resourcequota"), name)
+	}
+	return obj.(*api.ResourceQuota), nil
+}
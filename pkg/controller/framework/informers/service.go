@@ -0,0 +1,138 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"reflect"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// ServiceInformer is a type of SharedIndexInformer which watches and lists all services.
+// Interface provides constructor for informer and lister for services
+type ServiceInformer interface {
+	Informer() framework.SharedIndexInformer
+	Indexer() cache.Indexer
+	Lister() *ServiceLister
+}
+
+type serviceInformer struct {
+	*sharedInformerFactory
+}
+
+// CreateSharedServiceIndexInformer returns a SharedIndexInformer that lists and watches all services
+func CreateSharedServiceIndexInformer(client clientset.Interface, resyncPeriod time.Duration) framework.SharedIndexInformer {
+	sharedIndexInformer := framework.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+				return client.Core().Services(api.NamespaceAll).List(options)
+			},
+			WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+				return client.Core().Services(api.NamespaceAll).Watch(options)
+			},
+		},
+		&api.Service{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	return sharedIndexInformer
+}
+
+func (f *serviceInformer) Informer() framework.SharedIndexInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(&api.Service{})
+	informer, exists := f.informers[informerType]
+	if exists {
+		return informer
+	}
+	lw := f.listerWatcher(api.Resource("services"), &cache.ListWatch{
+		ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+			f.tweak(&options)
+			return f.client.Core().Services(f.namespace).List(options)
+		},
+		WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+			f.tweak(&options)
+			return f.client.Core().Services(f.namespace).Watch(options)
+		},
+	})
+	informer = framework.NewSharedIndexInformer(lw, &api.Service{}, f.defaultResync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	f.informers[informerType] = informer
+
+	return informer
+}
+
+func (f *serviceInformer) Indexer() cache.Indexer {
+	return f.Informer().GetIndexer()
+}
+
+func (f *serviceInformer) Lister() *ServiceLister {
+	return &ServiceLister{Indexer: f.Indexer()}
+}
+
+// ServiceLister helps list services from the shared informer's cache.
+type ServiceLister struct {
+	Indexer cache.Indexer
+}
+
+// List lists all services in the indexer matching selector.
+func (s *ServiceLister) List(selector labels.Selector) (ret []*api.Service, err error) {
+	err = cache.ListAll(s.Indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.Service))
+	})
+	return ret, err
+}
+
+// Services returns a lister scoped to namespace.
+func (s *ServiceLister) Services(namespace string) serviceNamespaceLister {
+	return serviceNamespaceLister{indexer: s.Indexer, namespace: namespace}
+}
+
+type serviceNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all services in the indexer for a given namespace matching selector.
+func (s serviceNamespaceLister) List(selector labels.Selector) (ret []*api.Service, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*api.Service))
+	})
+	return ret, err
+}
+
+// Get retrieves the service for a given namespace and name.
+func (s serviceNamespaceLister) Get(name string) (*api.Service, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(api.Resource("service"), name)
+	}
+	return obj.(*api.Service), nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"reflect"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/apis/apps"
+	"k8s.io/kubernetes/pkg/client/cache"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// StatefulSetInformer is a type of SharedIndexInformer which watches and lists all stateful sets.
+// Interface provides constructor for informer and lister for stateful sets
+type StatefulSetInformer interface {
+	Informer() framework.SharedIndexInformer
+	Indexer() cache.Indexer
+	Lister() *StatefulSetLister
+}
+
+type statefulSetInformer struct {
+	*sharedInformerFactory
+}
+
+// CreateSharedStatefulSetIndexInformer returns a SharedIndexInformer that lists and watches all stateful sets
+func CreateSharedStatefulSetIndexInformer(client clientset.Interface, resyncPeriod time.Duration) framework.SharedIndexInformer {
+	sharedIndexInformer := framework.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+				return client.Apps().StatefulSets(api.NamespaceAll).List(options)
+			},
+			WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+				return client.Apps().StatefulSets(api.NamespaceAll).Watch(options)
+			},
+		},
+		&apps.StatefulSet{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	return sharedIndexInformer
+}
+
+func (f *statefulSetInformer) Informer() framework.SharedIndexInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(&apps.StatefulSet{})
+	informer, exists := f.informers[informerType]
+	if exists {
+		return informer
+	}
+	lw := f.listerWatcher(apps.Resource("statefulsets"), &cache.ListWatch{
+		ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+			f.tweak(&options)
+			return f.client.Apps().StatefulSets(f.namespace).List(options)
+		},
+		WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+			f.tweak(&options)
+			return f.client.Apps().StatefulSets(f.namespace).Watch(options)
+		},
+	})
+	informer = framework.NewSharedIndexInformer(lw, &apps.StatefulSet{}, f.defaultResync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	f.informers[informerType] = informer
+
+	return informer
+}
+
+func (f *statefulSetInformer) Indexer() cache.Indexer {
+	return f.Informer().GetIndexer()
+}
+
+func (f *statefulSetInformer) Lister() *StatefulSetLister {
+	return &StatefulSetLister{Indexer: f.Indexer()}
+}
+
+// StatefulSetLister helps list stateful sets from the shared informer's cache.
+type StatefulSetLister struct {
+	Indexer cache.Indexer
+}
+
+// List lists all stateful sets in the indexer matching selector.
+func (s *StatefulSetLister) List(selector labels.Selector) (ret []*apps.StatefulSet, err error) {
+	err = cache.ListAll(s.Indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*apps.StatefulSet))
+	})
+	return ret, err
+}
+
+// StatefulSets returns a lister scoped to namespace.
+func (s *StatefulSetLister) StatefulSets(namespace string) statefulSetNamespaceLister {
+	return statefulSetNamespaceLister{indexer: s.Indexer, namespace: namespace}
+}
+
+type statefulSetNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all stateful sets in the indexer for a given namespace matching selector.
+func (s statefulSetNamespaceLister) List(selector labels.Selector) (ret []*apps.StatefulSet, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*apps.StatefulSet))
+	})
+	return ret, err
+}
+
+// Get retrieves the stateful set for a given namespace and name.
+func (s statefulSetNamespaceLister) Get(name string) (*apps.StatefulSet, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(apps.Resource("statefulset"), name)
+	}
+	return obj.(*apps.StatefulSet), nil
+}
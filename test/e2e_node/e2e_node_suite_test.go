@@ -44,6 +44,13 @@ var e2es *e2eService
 
 var prePullImages = flag.Bool("prepull-images", true, "If true, prepull images so image pull failures do not cause test failures.")
 var junitFileNumber = flag.Int("junit-file-number", 1, "Used to create junit filename - e.g. junit_01.xml.")
+var scrapeMetrics = flag.Bool("scrape-metrics", false, "If true, scrape the kubelet's /metrics endpoint throughout the suite and dump the raw series to --report-dir.")
+var metricsScrapeInterval = flag.Duration("metrics-scrape-interval", 10*time.Second, "How often to scrape the kubelet's /metrics endpoint when --scrape-metrics is set.")
+var metricsRetention = flag.Duration("metrics-retention", 1*time.Hour, "How much history to keep in memory when --scrape-metrics is set.")
+
+// metrics is non-nil for the duration of the suite when --scrape-metrics is
+// set; tests can assert against it with matchers like HaveCounterIncreased.
+var metrics *MetricsScraper
 
 func init() {
 	framework.RegisterCommonFlags()
@@ -106,12 +113,27 @@ var _ = BeforeSuite(func() {
 		glog.Infof("Running tests without starting services.")
 	}
 
+	if *scrapeMetrics {
+		metrics = NewMetricsScraper("http://127.0.0.1:10255/metrics", *metricsScrapeInterval, *metricsRetention)
+		metrics.Start()
+	}
+
 	// Reference common test to make the import valid.
 	commontest.CurrentSuite = commontest.NodeE2E
 })
 
 // Tear down the kubelet on the node
 var _ = AfterSuite(func() {
+	if metrics != nil {
+		metrics.Stop()
+		if *reportDir != "" {
+			dumpPath := path.Join(*reportDir, "metrics.json")
+			if err := metrics.DumpTo(dumpPath); err != nil {
+				glog.Errorf("Failed dumping scraped metrics to %s: %v", dumpPath, err)
+			}
+		}
+	}
+
 	if e2es != nil {
 		e2es.getLogFiles()
 		if *startServices && *stopServices {
@@ -0,0 +1,229 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_node
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+var prePullConfigFile = flag.String("prepull-config", "", "yaml or json file listing the images (and optional registry credentials) to pre-pull before the suite runs; overrides the built-in default image list")
+
+// containerRuntime identifies the runtime the node under test is running.
+// It takes the same values as the kubelet's --container-runtime flag (e.g.
+// "docker" or "remote"), and is passed through by runner/container_runtime.go
+// via ContainerRuntime.KubeletFlags() when the suite is driving a non-docker
+// node. pullImage only knows how to pull through `docker pull`, so
+// --prepull-config is rejected up front for any other runtime rather than
+// silently pulling nothing on the node.
+var containerRuntime = flag.String("container-runtime", "docker", "The container runtime the node under test is running. --prepull-config is only supported when this is \"docker\".")
+
+// defaultPrePullImages are pulled when --prepull-config is not set, preserving
+// the suite's previous "just pull what we need" behavior.
+var defaultPrePullImages = []string{
+	"gcr.io/google_containers/pause-amd64:3.0",
+	"gcr.io/google_containers/busybox:1.24",
+}
+
+// PrePullConfig describes the set of images PrePullAllImages should fetch,
+// and how to authenticate against their registries. It is parsed from the
+// file given by --prepull-config, e.g.:
+//
+//     images:
+//       - image: myregistry.example.com/my-image:v1
+//         auth:
+//           username: someuser
+//           password: somepass
+//       - image: otherregistry.example.com/other-image:v1
+//         auth:
+//           credentialHelper: ecr-login
+type PrePullConfig struct {
+	Images []ImagePullSpec `json:"images"`
+}
+
+// ImagePullSpec is a single image reference plus the optional credentials
+// needed to pull it.
+type ImagePullSpec struct {
+	Image string        `json:"image"`
+	Auth  *RegistryAuth `json:"auth,omitempty"`
+}
+
+// RegistryAuth carries one of the supported ways of authenticating to an OCI
+// registry for a pre-pulled image. Exactly one field should be set.
+type RegistryAuth struct {
+	// Username/Password authenticate via a plain `docker login`.
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// DockerConfigPath points at an existing docker config.json to use for
+	// this pull, e.g. one mounted onto the node out of band.
+	DockerConfigPath string `json:"dockerConfigPath,omitempty"`
+	// CredentialHelper names a `docker-credential-<name>` binary on PATH,
+	// following the same convention as go-containerregistry/crane.
+	CredentialHelper string `json:"credentialHelper,omitempty"`
+}
+
+// imagePullResult records the outcome of pulling a single image so failures
+// can be reported individually instead of collapsing to one boolean.
+type imagePullResult struct {
+	image string
+	err   error
+}
+
+// PrePullAllImages pre-pulls every image named in --prepull-config (or the
+// built-in default list when that flag is unset) so that later test flakes
+// are not confused with image pull failures. It authenticates each pull
+// according to the image's RegistryAuth, if any, and returns an error
+// listing every image that failed to pull rather than failing on the first
+// one, so a single flaky registry doesn't hide failures in the rest.
+func PrePullAllImages() error {
+	if *prePullConfigFile != "" && *containerRuntime != "docker" {
+		return fmt.Errorf("--prepull-config is set but --container-runtime is %q: pullImage only supports docker, so pre-pulling would silently do nothing on this node", *containerRuntime)
+	}
+
+	images, err := loadPrePullImages()
+	if err != nil {
+		return err
+	}
+
+	results := make(chan imagePullResult, len(images))
+	for _, img := range images {
+		go func(img ImagePullSpec) {
+			results <- imagePullResult{image: img.Image, err: pullImage(img)}
+		}(img)
+	}
+
+	var failures []string
+	for i := 0; i < len(images); i++ {
+		r := <-results
+		if r.err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", r.image, r.err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to pre-pull %d of %d image(s):\n%s", len(failures), len(images), strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+func loadPrePullImages() ([]ImagePullSpec, error) {
+	if *prePullConfigFile == "" {
+		specs := make([]ImagePullSpec, 0, len(defaultPrePullImages))
+		for _, image := range defaultPrePullImages {
+			specs = append(specs, ImagePullSpec{Image: image})
+		}
+		return specs, nil
+	}
+
+	data, err := ioutil.ReadFile(*prePullConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read prepull config %q: %v", *prePullConfigFile, err)
+	}
+	cfg := &PrePullConfig{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("could not parse prepull config %q: %v", *prePullConfigFile, err)
+	}
+	return cfg.Images, nil
+}
+
+// pullImage authenticates (if needed) and pulls a single image.
+//
+// This shells out to `sudo docker pull` rather than going through the
+// kubelet's image manager over CRI, so it only works on nodes actually
+// running Docker. PrePullAllImages rejects --prepull-config up front for
+// any other --container-runtime, but the built-in default list is still
+// pulled through docker whatever --container-runtime is.
+func pullImage(img ImagePullSpec) error {
+	dockerConfigDir, cleanup, err := setupRegistryAuth(img.Image, img.Auth)
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("sudo", "docker", "pull", img.Image)
+	if dockerConfigDir != "" {
+		cmd.Env = append(os.Environ(), "DOCKER_CONFIG="+dockerConfigDir)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("docker pull failed: %v, output: %q", err, string(output))
+	}
+	return nil
+}
+
+// setupRegistryAuth prepares whatever credential material auth requires and
+// returns a DOCKER_CONFIG directory to use for the pull, if any, plus a
+// cleanup func to remove temporary files it created.
+func setupRegistryAuth(image string, auth *RegistryAuth) (dockerConfigDir string, cleanup func(), err error) {
+	if auth == nil {
+		return "", nil, nil
+	}
+	registry := registryHost(image)
+
+	switch {
+	case auth.Username != "":
+		dir, err := ioutil.TempDir("", "prepull-docker-config")
+		if err != nil {
+			return "", nil, fmt.Errorf("could not create temp docker config dir: %v", err)
+		}
+		cleanup := func() { os.RemoveAll(dir) }
+
+		cmd := exec.Command("sudo", "docker", "login",
+			"--username", auth.Username, "--password", auth.Password, registry)
+		cmd.Env = append(os.Environ(), "DOCKER_CONFIG="+dir)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("docker login to %s failed: %v, output: %q", registry, err, string(output))
+		}
+		return dir, cleanup, nil
+
+	case auth.DockerConfigPath != "":
+		return filepath.Dir(auth.DockerConfigPath), nil, nil
+
+	case auth.CredentialHelper != "":
+		dir, err := ioutil.TempDir("", "prepull-docker-config")
+		if err != nil {
+			return "", nil, fmt.Errorf("could not create temp docker config dir: %v", err)
+		}
+		cleanup := func() { os.RemoveAll(dir) }
+		config := fmt.Sprintf(`{"credHelpers":{"%s":"%s"}}`, registry, auth.CredentialHelper)
+		if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0600); err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("could not write docker config for credential helper: %v", err)
+		}
+		return dir, cleanup, nil
+	}
+	return "", nil, nil
+}
+
+// registryHost returns the registry portion of an image reference, e.g.
+// "gcr.io" for "gcr.io/google_containers/pause:3.0", defaulting to Docker
+// Hub for unqualified references.
+func registryHost(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.Contains(parts[0], ".") || strings.Contains(parts[0], ":")) {
+		return parts[0]
+	}
+	return "docker.io"
+}
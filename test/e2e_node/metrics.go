@@ -0,0 +1,259 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/onsi/gomega/types"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// metricSample is one observation of a single time series scraped off the
+// kubelet's /metrics endpoint.
+type metricSample struct {
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels"`
+	Value     float64           `json:"value"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// MetricsScraper periodically scrapes a Prometheus-format metrics endpoint
+// and buffers the resulting samples in memory, trimming anything older than
+// retention. It's used in-process by the node e2e suite so tests can assert
+// on how kubelet metrics change over the course of a test without standing
+// up real Prometheus infrastructure.
+type MetricsScraper struct {
+	url           string
+	scrapeInterval time.Duration
+	retention     time.Duration
+
+	lock    sync.Mutex
+	samples []metricSample
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+}
+
+// NewMetricsScraper creates a scraper for url that keeps retention worth of
+// history, polling every scrapeInterval once Start is called.
+func NewMetricsScraper(url string, scrapeInterval, retention time.Duration) *MetricsScraper {
+	return &MetricsScraper{
+		url:            url,
+		scrapeInterval: scrapeInterval,
+		retention:      retention,
+	}
+}
+
+// Start begins scraping in the background. It is not safe to call Start
+// more than once on the same scraper.
+func (s *MetricsScraper) Start() {
+	s.stopCh = make(chan struct{})
+	s.doneCh = make(chan struct{})
+	go func() {
+		defer close(s.doneCh)
+		ticker := time.NewTicker(s.scrapeInterval)
+		defer ticker.Stop()
+		for {
+			if err := s.scrapeOnce(); err != nil {
+				glog.Warningf("Failed to scrape metrics from %s: %v", s.url, err)
+			}
+			select {
+			case <-s.stopCh:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop ends the background scrape loop and waits for it to exit.
+func (s *MetricsScraper) Stop() {
+	if s.stopCh == nil {
+		return
+	}
+	close(s.stopCh)
+	<-s.doneCh
+}
+
+func (s *MetricsScraper) scrapeOnce() error {
+	resp, err := http.Get(s.url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d scraping %s", resp.StatusCode, s.url)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not parse metrics from %s: %v", s.url, err)
+	}
+
+	now := time.Now()
+	var samples []metricSample
+	for name, family := range families {
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string, len(m.GetLabel()))
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			samples = append(samples, metricSample{
+				Name:      name,
+				Labels:    labels,
+				Value:     metricValue(m),
+				Timestamp: now,
+			})
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.samples = append(s.samples, samples...)
+	s.trimLocked(now)
+	return nil
+}
+
+func (s *MetricsScraper) trimLocked(now time.Time) {
+	cutoff := now.Add(-s.retention)
+	kept := s.samples[:0]
+	for _, sample := range s.samples {
+		if sample.Timestamp.After(cutoff) {
+			kept = append(kept, sample)
+		}
+	}
+	s.samples = kept
+}
+
+// Series returns the buffered samples for name whose labels satisfy
+// labelSelector (a single "key=value" pair, or "" to match any series named
+// name), ordered oldest first.
+func (s *MetricsScraper) Series(name, labelSelector string) []metricSample {
+	key, value := splitLabelSelector(labelSelector)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var series []metricSample
+	for _, sample := range s.samples {
+		if sample.Name != name {
+			continue
+		}
+		if key != "" && sample.Labels[key] != value {
+			continue
+		}
+		series = append(series, sample)
+	}
+	return series
+}
+
+// DumpTo writes every buffered sample, as newline-delimited JSON, to path so
+// the raw series can be analyzed after the suite exits.
+func (s *MetricsScraper) DumpTo(path string) error {
+	s.lock.Lock()
+	samples := make([]metricSample, len(s.samples))
+	copy(samples, s.samples)
+	s.lock.Unlock()
+
+	var buf bytes.Buffer
+	for _, sample := range samples {
+		data, err := json.Marshal(sample)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
+
+func splitLabelSelector(selector string) (key, value string) {
+	parts := strings.SplitN(selector, "=", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+	return parts[0], parts[1]
+}
+
+// HaveCounterIncreased succeeds when the latest buffered value of the named
+// series matching labelSelector is greater than its first buffered value; for
+// a histogram or summary that value is its sample count (see metricValue),
+// e.g.:
+//
+//     Expect(metrics).To(HaveCounterIncreased("kubelet_runtime_operations_total", "operation_type=pull_image"))
+func HaveCounterIncreased(name, labelSelector string) types.GomegaMatcher {
+	return &haveCounterIncreasedMatcher{name: name, labelSelector: labelSelector}
+}
+
+type haveCounterIncreasedMatcher struct {
+	name          string
+	labelSelector string
+	first, last   float64
+}
+
+func (m *haveCounterIncreasedMatcher) Match(actual interface{}) (bool, error) {
+	scraper, ok := actual.(*MetricsScraper)
+	if !ok {
+		return false, fmt.Errorf("HaveCounterIncreased expects a *MetricsScraper, got %T", actual)
+	}
+	series := scraper.Series(m.name, m.labelSelector)
+	if len(series) == 0 {
+		return false, fmt.Errorf("no samples found for metric %q matching %q; is --scrape-metrics enabled?", m.name, m.labelSelector)
+	}
+	m.first = series[0].Value
+	m.last = series[len(series)-1].Value
+	return m.last > m.first, nil
+}
+
+func (m *haveCounterIncreasedMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected counter %q{%s} to have increased, but it went from %v to %v", m.name, m.labelSelector, m.first, m.last)
+}
+
+func (m *haveCounterIncreasedMatcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected counter %q{%s} not to have increased, but it went from %v to %v", m.name, m.labelSelector, m.first, m.last)
+}
+
+// metricValue pulls the single scalar value out of a metric regardless of
+// its type, treating histograms/summaries as their sample count so
+// HaveCounterIncreased can be used against them too.
+func metricValue(m *dto.Metric) float64 {
+	if c := m.GetCounter(); c != nil {
+		return c.GetValue()
+	}
+	if g := m.GetGauge(); g != nil {
+		return g.GetValue()
+	}
+	if h := m.GetHistogram(); h != nil {
+		return float64(h.GetSampleCount())
+	}
+	if sum := m.GetSummary(); sum != nil {
+		return float64(sum.GetSampleCount())
+	}
+	if u := m.GetUntyped(); u != nil {
+		return u.GetValue()
+	}
+	return 0
+}
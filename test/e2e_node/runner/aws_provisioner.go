@@ -0,0 +1,94 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// awsProvisioner provisions instances on AWS EC2 by shelling out to the aws
+// cli, mirroring how gceProvisioner drives instances through the gcloud
+// client libraries. Shelling out avoids vendoring the full AWS SDK just for
+// this one runner.
+type awsProvisioner struct{}
+
+func (*awsProvisioner) Name() string { return "aws" }
+
+func (p *awsProvisioner) Create(image Image) (string, error) {
+	region := image.Region
+	if region == "" {
+		return "", fmt.Errorf("aws provisioner requires a region to be set for image %s", image.Image)
+	}
+	out, err := exec.Command("aws", "ec2", "run-instances",
+		"--region", region,
+		"--image-id", image.Image,
+		"--instance-type", "t2.medium",
+		"--count", "1",
+		"--query", "Instances[0].InstanceId",
+		"--output", "text").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("could not launch ec2 instance for image %s: %v: %s", image.Image, err, out)
+	}
+	instanceId := strings.TrimSpace(string(out))
+
+	out, err = exec.Command("aws", "ec2", "wait", "instance-running",
+		"--region", region, "--instance-ids", instanceId).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("instance %s did not reach running state: %v: %s", instanceId, err, out)
+	}
+
+	out, err = exec.Command("aws", "ec2", "describe-instances",
+		"--region", region,
+		"--instance-ids", instanceId,
+		"--query", "Reservations[0].Instances[0].PublicDnsName",
+		"--output", "text").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("could not look up public dns name for %s: %v: %s", instanceId, err, out)
+	}
+
+	awsInstances.Lock()
+	awsInstances.byImage[image.Image] = instanceId
+	awsInstances.Unlock()
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (p *awsProvisioner) Delete(image Image) error {
+	awsInstances.Lock()
+	instanceId, ok := awsInstances.byImage[image.Image]
+	delete(awsInstances.byImage, image.Image)
+	awsInstances.Unlock()
+	if !ok {
+		return nil
+	}
+
+	out, err := exec.Command("aws", "ec2", "terminate-instances",
+		"--region", image.Region, "--instance-ids", instanceId).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("could not terminate instance %s: %v: %s", instanceId, err, out)
+	}
+	return nil
+}
+
+// awsInstances tracks the instance launched per image so Delete can find it
+// again even though Create/Delete run concurrently across images.
+var awsInstances = struct {
+	sync.Mutex
+	byImage map[string]string
+}{byImage: map[string]string{}}
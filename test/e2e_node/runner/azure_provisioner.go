@@ -0,0 +1,81 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// azureProvisioner provisions instances on Azure by shelling out to the az
+// cli, following the same pattern as awsProvisioner.
+type azureProvisioner struct{}
+
+func (*azureProvisioner) Name() string { return "azure" }
+
+func (p *azureProvisioner) Create(image Image) (string, error) {
+	if image.Region == "" {
+		return "", fmt.Errorf("azure provisioner requires a region to be set for image %s", image.Image)
+	}
+	vmName := *instanceNamePrefix + "-" + image.Image
+	resourceGroup := vmName
+
+	if out, err := exec.Command("az", "group", "create", "--name", resourceGroup, "--location", image.Region).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("could not create resource group %s: %v: %s", resourceGroup, err, out)
+	}
+
+	out, err := exec.Command("az", "vm", "create",
+		"--resource-group", resourceGroup,
+		"--name", vmName,
+		"--image", image.Image,
+		"--size", "Standard_D2_v2",
+		"--query", "publicIpAddress",
+		"--output", "tsv").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("could not create azure vm for image %s: %v: %s", image.Image, err, out)
+	}
+
+	azureResourceGroups.Lock()
+	azureResourceGroups.byImage[image.Image] = resourceGroup
+	azureResourceGroups.Unlock()
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (p *azureProvisioner) Delete(image Image) error {
+	azureResourceGroups.Lock()
+	resourceGroup, ok := azureResourceGroups.byImage[image.Image]
+	delete(azureResourceGroups.byImage, image.Image)
+	azureResourceGroups.Unlock()
+	if !ok {
+		return nil
+	}
+
+	out, err := exec.Command("az", "group", "delete", "--name", resourceGroup, "--yes", "--no-wait").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("could not delete resource group %s: %v: %s", resourceGroup, err, out)
+	}
+	return nil
+}
+
+// azureResourceGroups tracks the resource group created per image, mirroring
+// awsInstances, so concurrent Create/Delete calls don't race on each other.
+var azureResourceGroups = struct {
+	sync.Mutex
+	byImage map[string]string
+}{byImage: map[string]string{}}
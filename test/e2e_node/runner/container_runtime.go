@@ -0,0 +1,93 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "fmt"
+
+// ContainerRuntime abstracts over the container runtime that a provisioned
+// instance should run, so the same suite can exercise the node across
+// Docker, containerd and CRI-O from one run_e2e.go invocation by setting
+// "runtime:" per image in ImageConfig.
+type ContainerRuntime interface {
+	// Name is the "runtime" string used in ImageConfig, e.g. "docker".
+	Name() string
+
+	// InstallCommand is the remote command that installs and starts the
+	// runtime on a freshly provisioned instance, or nil if the image is
+	// expected to already have it (as GCE's default docker-based images do).
+	InstallCommand() []string
+
+	// VerifyCommand is the remote command used to confirm the runtime is up
+	// and serving before tests are copied over.
+	VerifyCommand() []string
+
+	// KubeletFlags returns the --container-runtime/--container-runtime-endpoint
+	// flags to append to --test_args so the kubelet that e2eService launches
+	// on the instance talks to this runtime.
+	KubeletFlags() string
+}
+
+type dockerRuntime struct{}
+
+func (dockerRuntime) Name() string             { return "docker" }
+func (dockerRuntime) InstallCommand() []string { return nil }
+func (dockerRuntime) VerifyCommand() []string  { return []string{"sudo", "docker", "version"} }
+func (dockerRuntime) KubeletFlags() string     { return "--container-runtime=docker" }
+
+type containerdRuntime struct{}
+
+func (containerdRuntime) Name() string { return "containerd" }
+func (containerdRuntime) InstallCommand() []string {
+	return []string{"sudo", "bash", "-c", "apt-get update && apt-get install -y containerd && systemctl start containerd"}
+}
+func (containerdRuntime) VerifyCommand() []string { return []string{"sudo", "ctr", "version"} }
+func (containerdRuntime) KubeletFlags() string {
+	return "--container-runtime=remote --container-runtime-endpoint=unix:///run/containerd/containerd.sock"
+}
+
+type crioRuntime struct{}
+
+func (crioRuntime) Name() string { return "crio" }
+func (crioRuntime) InstallCommand() []string {
+	return []string{"sudo", "bash", "-c", "apt-get update && apt-get install -y cri-o && systemctl start crio"}
+}
+func (crioRuntime) VerifyCommand() []string { return []string{"sudo", "crictl", "info"} }
+func (crioRuntime) KubeletFlags() string {
+	return "--container-runtime=remote --container-runtime-endpoint=unix:///var/run/crio/crio.sock"
+}
+
+// containerRuntimes holds the known ContainerRuntime implementations keyed
+// by the "runtime" string used in ImageConfig.
+var containerRuntimes = map[string]ContainerRuntime{
+	"docker":     dockerRuntime{},
+	"containerd": containerdRuntime{},
+	"crio":       crioRuntime{},
+}
+
+// getContainerRuntime returns the ContainerRuntime registered for name,
+// defaulting to docker for backward compatibility with configs that don't
+// set "runtime".
+func getContainerRuntime(name string) (ContainerRuntime, error) {
+	if name == "" {
+		name = "docker"
+	}
+	rt, ok := containerRuntimes[name]
+	if !ok {
+		return nil, fmt.Errorf("no container runtime registered for %q", name)
+	}
+	return rt, nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/kubernetes/test/e2e_node"
+
+	"github.com/golang/glog"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/compute/v1"
+)
+
+// gceProvisioner provisions instances on Google Compute Engine. It is the
+// original, and still default, backend for run_e2e.go.
+type gceProvisioner struct{}
+
+func (*gceProvisioner) Name() string { return "gce" }
+
+// Create provisions a gce instance using image.
+func (p *gceProvisioner) Create(image Image) (string, error) {
+	runtime, err := getContainerRuntime(image.Runtime)
+	if err != nil {
+		return "", err
+	}
+
+	if computeService == nil {
+		var err error
+		computeService, err = getComputeClient()
+		if err != nil {
+			return "", fmt.Errorf("unable to create gcloud compute service using defaults.  Make sure you are authenticated. %v", err)
+		}
+	}
+
+	name := imageToInstanceName(image.Image)
+	i := &compute.Instance{
+		Name:        name,
+		MachineType: machineType(),
+		NetworkInterfaces: []*compute.NetworkInterface{
+			{
+				AccessConfigs: []*compute.AccessConfig{
+					{
+						Type: "ONE_TO_ONE_NAT",
+						Name: "External NAT",
+					},
+				}},
+		},
+		Disks: []*compute.AttachedDisk{
+			{
+				AutoDelete: true,
+				Boot:       true,
+				Type:       "PERSISTENT",
+				InitializeParams: &compute.AttachedDiskInitializeParams{
+					SourceImage: sourceImage(image.Image, image.Project),
+				},
+			},
+		},
+	}
+	if *instanceMetadata != "" {
+		raw := parseInstanceMetadata(*instanceMetadata)
+		i.Metadata = &compute.Metadata{}
+		metadata := []*compute.MetadataItems{}
+		for k, v := range raw {
+			val := v
+			metadata = append(metadata, &compute.MetadataItems{
+				Key:   k,
+				Value: &val,
+			})
+		}
+		i.Metadata.Items = metadata
+	}
+	op, err := computeService.Instances.Insert(*project, *zone, i).Do()
+	if err != nil {
+		return "", err
+	}
+	if op.Error != nil {
+		return "", fmt.Errorf("could not create instance %s: %+v", name, op.Error)
+	}
+
+	instanceRunning := false
+	for i := 0; i < 30 && !instanceRunning; i++ {
+		if i > 0 {
+			time.Sleep(time.Second * 20)
+		}
+		var instance *compute.Instance
+		instance, err = computeService.Instances.Get(*project, *zone, name).Do()
+		if err != nil {
+			continue
+		}
+		if strings.ToUpper(instance.Status) != "RUNNING" {
+			err = fmt.Errorf("instance %s not in state RUNNING, was %s.", name, instance.Status)
+			continue
+		}
+		externalIp := getExternalIp(instance)
+		if len(externalIp) > 0 {
+			e2e_node.AddHostnameIp(name, externalIp)
+		}
+
+		if install := runtime.InstallCommand(); install != nil {
+			var output string
+			output, err = e2e_node.RunSshCommand(append([]string{"ssh", e2e_node.GetHostnameOrIp(name), "--"}, install...)...)
+			if err != nil {
+				err = fmt.Errorf("instance %s could not install %s - Command failed: %s", name, runtime.Name(), output)
+				continue
+			}
+		}
+
+		var output string
+		output, err = e2e_node.RunSshCommand(append([]string{"ssh", e2e_node.GetHostnameOrIp(name), "--"}, runtime.VerifyCommand()...)...)
+		if err != nil {
+			err = fmt.Errorf("instance %s not running %s - Command failed: %s", name, runtime.Name(), output)
+			continue
+		}
+		instanceRunning = true
+	}
+	return name, err
+}
+
+// Delete tears down the gce instance created for image.
+func (p *gceProvisioner) Delete(image Image) error {
+	_, err := computeService.Instances.Delete(*project, *zone, imageToInstanceName(image.Image)).Do()
+	if err != nil {
+		glog.Infof("Error deleting instance %s", imageToInstanceName(image.Image))
+	}
+	return err
+}
+
+func getExternalIp(instance *compute.Instance) string {
+	for i := range instance.NetworkInterfaces {
+		ni := instance.NetworkInterfaces[i]
+		for j := range ni.AccessConfigs {
+			ac := ni.AccessConfigs[j]
+			if len(ac.NatIP) > 0 {
+				return ac.NatIP
+			}
+		}
+	}
+	return ""
+}
+
+func getComputeClient() (*compute.Service, error) {
+	const retries = 10
+	const backoff = time.Second * 6
+
+	// Setup the gce client for provisioning instances
+	// Getting credentials on gce jenkins is flaky, so try a couple times
+	var err error
+	var cs *compute.Service
+	for i := 0; i < retries; i++ {
+		if i > 0 {
+			time.Sleep(backoff)
+		}
+
+		var client *http.Client
+		client, err = google.DefaultClient(oauth2.NoContext, compute.ComputeScope)
+		if err != nil {
+			continue
+		}
+
+		cs, err = compute.New(client)
+		if err != nil {
+			continue
+		}
+		return cs, nil
+	}
+	return nil, err
+}
+
+func imageToInstanceName(image string) string {
+	return *instanceNamePrefix + "-" + image
+}
+
+func sourceImage(image, imageProject string) string {
+	return fmt.Sprintf("projects/%s/global/images/%s", imageProject, image)
+}
+
+func machineType() string {
+	return fmt.Sprintf("zones/%s/machineTypes/n1-standard-1", *zone)
+}
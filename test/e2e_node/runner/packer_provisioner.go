@@ -0,0 +1,92 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// packerProvisioner runs tests against a local VM built from a packer
+// template, for developers and CI systems that don't want to depend on a
+// cloud project. image.Image is the path to the packer template (json or
+// hcl); image.Project is not used here, though run_e2e.go still rejects an
+// image entry that leaves it empty.
+type packerProvisioner struct{}
+
+func (*packerProvisioner) Name() string { return "packer" }
+
+func (p *packerProvisioner) Create(image Image) (string, error) {
+	domain := *instanceNamePrefix + "-" + strings.Replace(image.Image, "/", "-", -1)
+
+	if out, err := exec.Command("packer", "build", "-var", fmt.Sprintf("vm_name=%s", domain), image.Image).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("packer build of %s failed: %v: %s", image.Image, err, out)
+	}
+
+	out, err := exec.Command("virsh", "domifaddr", domain).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("could not look up address for local vm %s: %v: %s", domain, err, out)
+	}
+	host, err := parseVirshIp(string(out))
+	if err != nil {
+		return "", err
+	}
+
+	packerDomains.Lock()
+	packerDomains.byImage[image.Image] = domain
+	packerDomains.Unlock()
+	return host, nil
+}
+
+func (p *packerProvisioner) Delete(image Image) error {
+	packerDomains.Lock()
+	domain, ok := packerDomains.byImage[image.Image]
+	delete(packerDomains.byImage, image.Image)
+	packerDomains.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if out, err := exec.Command("virsh", "destroy", domain).CombinedOutput(); err != nil {
+		return fmt.Errorf("could not destroy local vm %s: %v: %s", domain, err, out)
+	}
+	if out, err := exec.Command("virsh", "undefine", domain, "--remove-all-storage").CombinedOutput(); err != nil {
+		return fmt.Errorf("could not undefine local vm %s: %v: %s", domain, err, out)
+	}
+	return nil
+}
+
+// parseVirshIp extracts the first ipv4 address out of `virsh domifaddr` output.
+func parseVirshIp(out string) (string, error) {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		for _, field := range fields {
+			if strings.Contains(field, "/") && strings.Count(field, ".") == 3 {
+				return strings.SplitN(field, "/", 2)[0], nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no ipv4 address found in virsh domifaddr output: %q", out)
+}
+
+// packerDomains tracks the libvirt domain created per image.
+var packerDomains = struct {
+	sync.Mutex
+	byImage map[string]string
+}{byImage: map[string]string{}}
@@ -0,0 +1,64 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "fmt"
+
+// Provisioner provisions and tears down the remote instance that a single
+// image entry in an ImageConfig is tested against. Each supported cloud (and
+// the local/packer-built VM backend) implements this interface so that
+// run_e2e.go can fan a single invocation out across heterogeneous backends.
+type Provisioner interface {
+	// Name returns the provider name used in ImageConfig's "provider" field,
+	// e.g. "gce", "aws", "azure", "packer".
+	Name() string
+
+	// Create provisions a host running image and returns the hostname or IP
+	// that the test archive can be copied to and run against over SSH.
+	Create(image Image) (host string, err error)
+
+	// Delete tears down the instance created for image, if any was created.
+	Delete(image Image) error
+}
+
+// provisioners holds the known Provisioner implementations keyed by the
+// "provider" string used in ImageConfig.
+var provisioners = map[string]Provisioner{}
+
+func registerProvisioner(p Provisioner) {
+	provisioners[p.Name()] = p
+}
+
+func init() {
+	registerProvisioner(&gceProvisioner{})
+	registerProvisioner(&awsProvisioner{})
+	registerProvisioner(&azureProvisioner{})
+	registerProvisioner(&packerProvisioner{})
+}
+
+// getProvisioner returns the Provisioner registered for name, defaulting to
+// gce for backward compatibility with configs that don't set "provider".
+func getProvisioner(name string) (Provisioner, error) {
+	if name == "" {
+		name = "gce"
+	}
+	p, ok := provisioners[name]
+	if !ok {
+		return nil, fmt.Errorf("no provisioner registered for provider %q", name)
+	}
+	return p, nil
+}
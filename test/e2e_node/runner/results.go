@@ -0,0 +1,118 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+)
+
+var resultsJSONFile = flag.String("results-json", "", "If set, write one newline-delimited JSON record per host/image to this file, enriched with CI environment metadata if any is detected.")
+
+// ciMetadata annotates every result record with the CI job that produced it,
+// auto-detected from the environment variables the job's CI system sets.
+type ciMetadata struct {
+	Provider  string `json:"provider"`
+	JobName   string `json:"job_name,omitempty"`
+	BuildID   string `json:"build_id,omitempty"`
+	PRNumber  string `json:"pr_number,omitempty"`
+	CommitSHA string `json:"commit_sha,omitempty"`
+}
+
+// detectCIMetadata recognizes Prow, GitHub Actions and Jenkins by the
+// environment variables each of them is documented to set, returning the
+// zero value when run outside of any of them (e.g. locally).
+func detectCIMetadata() *ciMetadata {
+	switch {
+	case os.Getenv("PROW_JOB_ID") != "":
+		return &ciMetadata{
+			Provider:  "prow",
+			JobName:   os.Getenv("JOB_NAME"),
+			BuildID:   os.Getenv("BUILD_ID"),
+			PRNumber:  os.Getenv("PULL_NUMBER"),
+			CommitSHA: os.Getenv("PULL_PULL_SHA"),
+		}
+	case os.Getenv("GITHUB_ACTIONS") != "":
+		return &ciMetadata{
+			Provider:  "github-actions",
+			JobName:   os.Getenv("GITHUB_WORKFLOW"),
+			BuildID:   os.Getenv("GITHUB_RUN_ID"),
+			CommitSHA: os.Getenv("GITHUB_SHA"),
+		}
+	case os.Getenv("JENKINS_URL") != "":
+		return &ciMetadata{
+			Provider:  "jenkins",
+			JobName:   os.Getenv("JOB_NAME"),
+			BuildID:   os.Getenv("BUILD_NUMBER"),
+			CommitSHA: os.Getenv("GIT_COMMIT"),
+		}
+	}
+	return nil
+}
+
+// resultRecord is the newline-delimited JSON shape written to --results-json,
+// one object per TestResult.
+type resultRecord struct {
+	Image        string      `json:"image"`
+	Host         string      `json:"host"`
+	Provider     string      `json:"provider,omitempty"`
+	Region       string      `json:"region,omitempty"`
+	DurationSecs float64     `json:"duration_seconds"`
+	ExitOk       bool        `json:"exit_ok"`
+	Error        string      `json:"error,omitempty"`
+	JunitPath    string      `json:"junit_path,omitempty"`
+	CI           *ciMetadata `json:"ci,omitempty"`
+}
+
+// writeResultsJSON writes one newline-delimited JSON record per result to
+// --results-json, if that flag was set. It replaces the old behavior of
+// only ever summarizing results as human-readable text on stdout, so
+// downstream dashboards can ingest node e2e runs without scraping logs.
+func writeResultsJSON(results []*TestResult) error {
+	if *resultsJSONFile == "" {
+		return nil
+	}
+
+	f, err := os.Create(*resultsJSONFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	ci := detectCIMetadata()
+	enc := json.NewEncoder(f)
+	for _, tr := range results {
+		record := resultRecord{
+			Image:        tr.image,
+			Host:         tr.host,
+			Provider:     tr.provider,
+			Region:       tr.region,
+			DurationSecs: tr.duration.Seconds(),
+			ExitOk:       tr.exitOk,
+			JunitPath:    tr.junitPath,
+			CI:           ci,
+		}
+		if tr.err != nil {
+			record.Error = tr.err.Error()
+		}
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
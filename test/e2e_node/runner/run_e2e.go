@@ -21,12 +21,13 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
-	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -36,8 +37,6 @@ import (
 	"github.com/ghodss/yaml"
 	"github.com/golang/glog"
 	"github.com/pborman/uuid"
-	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/google"
 	"google.golang.org/api/compute/v1"
 )
 
@@ -54,6 +53,7 @@ var deleteInstances = flag.Bool("delete-instances", true, "If true, delete any i
 var buildOnly = flag.Bool("build-only", false, "If true, build e2e_node_test.tar.gz and exit.")
 var setupNode = flag.Bool("setup-node", false, "When true, current user will be added to docker group on the test machine")
 var instanceMetadata = flag.String("instance-metadata", "", "key/value metadata for instances separated by '=' or '<', 'k=v' means the key is 'k' and the value is 'v'; 'k<p' means the key is 'k' and the value is extracted from the local path 'p', e.g. k1=v1,k2<p2")
+var reportDir = flag.String("report-dir", "", "directory the suite's junit reports are written to; required to merge per-shard reports when --shards-per-host > 1")
 
 var computeService *compute.Service
 
@@ -66,10 +66,15 @@ type Archive struct {
 var arc Archive
 
 type TestResult struct {
-	output string
-	err    error
-	host   string
-	exitOk bool
+	output    string
+	err       error
+	host      string
+	exitOk    bool
+	provider  string
+	region    string
+	image     string
+	duration  time.Duration
+	junitPath string
 }
 
 // ImageConfig specifies what images should be run and how for these tests.
@@ -81,13 +86,20 @@ type TestResult struct {
 //       short-name:
 //         image: gce-image-name
 //         project: gce-image-project
+//         provider: gce
 type ImageConfig struct {
-	Images map[string]GCEImage `json:"images"`
+	Images map[string]Image `json:"images"`
 }
 
-type GCEImage struct {
-	Image   string `json:"image"`
-	Project string `json:"project"`
+// Image describes a single image entry in an ImageConfig. Provider selects
+// which Provisioner (gce, aws, azure, packer) brings the instance up; it
+// defaults to "gce" when left empty so existing configs keep working.
+type Image struct {
+	Image    string `json:"image"`
+	Project  string `json:"project"`
+	Provider string `json:"provider"`
+	Region   string `json:"region"`
+	Runtime  string `json:"runtime"`
 }
 
 func main() {
@@ -103,7 +115,7 @@ func main() {
 		glog.Fatalf("Must specify one of --image-config-file, --hosts, --images.")
 	}
 	gceImages := &ImageConfig{
-		Images: make(map[string]GCEImage),
+		Images: make(map[string]Image),
 	}
 	if *imageConfigFile != "" {
 		// parse images
@@ -125,26 +137,43 @@ func main() {
 		}
 		cliImages := strings.Split(*images, ",")
 		for _, img := range cliImages {
-			gceImages.Images[img] = GCEImage{
-				Image:   img,
-				Project: *imageProject,
+			gceImages.Images[img] = Image{
+				Image:    img,
+				Project:  *imageProject,
+				Provider: "gce",
 			}
 		}
 	}
 
-	if len(gceImages.Images) != 0 && *zone == "" {
-		glog.Fatal("Must specify --zone flag")
-	}
+	needsGCEDefaults := false
 	for shortName, image := range gceImages.Images {
+		if image.Provider == "" {
+			image.Provider = "gce"
+			gceImages.Images[shortName] = image
+		}
+		if image.Provider == "gce" {
+			needsGCEDefaults = true
+		}
+		if _, err := getProvisioner(image.Provider); err != nil {
+			glog.Fatalf("Invalid config for %v: %v", shortName, err)
+		}
 		if image.Project == "" {
 			glog.Fatalf("Invalid config for %v; must specify a project", shortName)
 		}
-	}
-	if len(gceImages.Images) != 0 {
-		if *project == "" {
-			glog.Fatal("Must specify --project flag to launch images into")
+		// Only gceProvisioner's Create installs and verifies a non-docker runtime
+		// on the instance it provisions; aws/azure/packer always boot whatever
+		// runtime the image ships with, so a non-docker runtime there would
+		// silently point the kubelet at a socket nothing is serving.
+		if image.Provider != "gce" && image.Runtime != "" && image.Runtime != "docker" {
+			glog.Fatalf("Invalid config for %v: runtime %q is only supported on provider \"gce\"; aws/azure/packer images must already have their runtime installed and use runtime: docker (or leave runtime unset)", shortName, image.Runtime)
 		}
 	}
+	if needsGCEDefaults && *zone == "" {
+		glog.Fatal("Must specify --zone flag")
+	}
+	if needsGCEDefaults && *project == "" {
+		glog.Fatal("Must specify --project flag to launch images into")
+	}
 	if *instanceNamePrefix == "" {
 		*instanceNamePrefix = "tmp-node-e2e-" + uuid.NewUUID().String()[:8]
 	}
@@ -162,27 +191,34 @@ func main() {
 	go arc.getArchive()
 	defer arc.deleteArchive()
 
-	var err error
-	computeService, err = getComputeClient()
-	if err != nil {
-		glog.Fatalf("Unable to create gcloud compute service using defaults.  Make sure you are authenticated. %v", err)
+	if needsGCEDefaults || *hosts != "" {
+		var err error
+		computeService, err = getComputeClient()
+		if err != nil {
+			glog.Fatalf("Unable to create gcloud compute service using defaults.  Make sure you are authenticated. %v", err)
+		}
 	}
 
 	results := make(chan *TestResult)
 	running := 0
 	for shortName, image := range gceImages.Images {
 		running++
-		fmt.Printf("Initializing e2e tests using image %s.\n", shortName)
-		go func(image, imageProject string, junitFileNum int) {
-			results <- testImage(image, imageProject, junitFileNum)
-		}(image.Image, image.Project, running)
+		fmt.Printf("Initializing e2e tests using image %s on provider %s.\n", shortName, image.Provider)
+		go func(shortName string, image Image, junitFileNum int) {
+			result := testImage(image, junitFileNum)
+			result.image = shortName
+			results <- result
+		}(shortName, image, running)
 	}
 	if *hosts != "" {
 		for _, host := range strings.Split(*hosts, ",") {
 			fmt.Printf("Initializing e2e tests using host %s.\n", host)
 			running++
 			go func(host string, junitFileNum int) {
-				results <- testHost(host, *cleanup, junitFileNum, *setupNode)
+				result := testHost("gce", host, *cleanup, junitFileNum, *setupNode, "")
+				result.provider = "gce"
+				result.image = host
+				results <- result
 			}(host, running)
 		}
 	}
@@ -190,20 +226,26 @@ func main() {
 	// Wait for all tests to complete and emit the results
 	errCount := 0
 	exitOk := true
+	allResults := make([]*TestResult, 0, running)
 	for i := 0; i < running; i++ {
 		tr := <-results
+		allResults = append(allResults, tr)
 		host := tr.host
 		fmt.Printf("%s================================================================%s\n", blue, noColour)
 		if tr.err != nil {
 			errCount++
-			fmt.Printf("Failure Finished Host %s Test Suite\n%s\n%v\n", host, tr.output, tr.err)
+			fmt.Printf("Failure Finished Host %s [%s/%s] Test Suite\n%s\n%v\n", host, tr.provider, tr.region, tr.output, tr.err)
 		} else {
-			fmt.Printf("Success Finished Host %s Test Suite\n%s\n", host, tr.output)
+			fmt.Printf("Success Finished Host %s [%s/%s] Test Suite\n%s\n", host, tr.provider, tr.region, tr.output)
 		}
 		exitOk = exitOk && tr.exitOk
 		fmt.Printf("%s================================================================%s\n", blue, noColour)
 	}
 
+	if err := writeResultsJSON(allResults); err != nil {
+		glog.Errorf("Failed writing --results-json: %v", err)
+	}
+
 	// Set the exit code if there were failures
 	if !exitOk {
 		fmt.Printf("Failure: %d errors encountered.", errCount)
@@ -224,27 +266,35 @@ func (a *Archive) deleteArchive() {
 	os.Remove(path)
 }
 
-// Run tests in archive against host
-func testHost(host string, deleteFiles bool, junitFileNum int, setupNode bool) *TestResult {
-	instance, err := computeService.Instances.Get(*project, *zone, host).Do()
-	if err != nil {
-		return &TestResult{
-			err:    err,
-			host:   host,
-			exitOk: false,
+// Run tests in archive against host. The GCE instance-status check only
+// applies when provider is "gce": gceProvisioner.Create already polls the
+// instance to RUNNING and verifies the runtime before returning the host,
+// and for aws/azure/packer, host is a public DNS name/IP rather than a GCE
+// instance name, so looking it up in computeService would either panic on a
+// nil computeService (no gce image configured) or report a bogus "instance
+// not found" for a perfectly healthy host.
+func testHost(provider, host string, deleteFiles bool, junitFileNum int, setupNode bool, extraTestArgs string) *TestResult {
+	if provider == "gce" {
+		instance, err := computeService.Instances.Get(*project, *zone, host).Do()
+		if err != nil {
+			return &TestResult{
+				err:    err,
+				host:   host,
+				exitOk: false,
+			}
 		}
-	}
-	if strings.ToUpper(instance.Status) != "RUNNING" {
-		err = fmt.Errorf("instance %s not in state RUNNING, was %s.", host, instance.Status)
-		return &TestResult{
-			err:    err,
-			host:   host,
-			exitOk: false,
+		if strings.ToUpper(instance.Status) != "RUNNING" {
+			err = fmt.Errorf("instance %s not in state RUNNING, was %s.", host, instance.Status)
+			return &TestResult{
+				err:    err,
+				host:   host,
+				exitOk: false,
+			}
+		}
+		externalIp := getExternalIp(instance)
+		if len(externalIp) > 0 {
+			e2e_node.AddHostnameIp(host, externalIp)
 		}
-	}
-	externalIp := getExternalIp(instance)
-	if len(externalIp) > 0 {
-		e2e_node.AddHostnameIp(host, externalIp)
 	}
 
 	path, err := arc.getArchive()
@@ -255,160 +305,130 @@ func testHost(host string, deleteFiles bool, junitFileNum int, setupNode bool) *
 		}
 	}
 
-	output, exitOk, err := e2e_node.RunRemote(path, host, deleteFiles, junitFileNum, setupNode, *testArgs)
-	return &TestResult{
-		output: output,
-		err:    err,
-		host:   host,
-		exitOk: exitOk,
-	}
+	return runSuiteOnHost(path, host, deleteFiles, junitFileNum, setupNode, extraTestArgs)
 }
 
-// Provision a gce instance using image and run the tests in archive against the instance.
-// Delete the instance afterward.
-func testImage(image, imageProject string, junitFileNum int) *TestResult {
-	host, err := createInstance(image, imageProject)
-	if *deleteInstances {
-		defer deleteInstance(image)
-	}
-	if err != nil {
+// runSuiteOnHost runs the suite in archive against host, splitting it into
+// *shardsPerHost concurrent Ginkgo-focus shards when that flag is greater
+// than one. extraTestArgs (e.g. a container runtime's KubeletFlags) is
+// appended to --test_args for every shard. arc is a sync.Once-backed
+// singleton and e2e_node's SSH helpers key connections off the host string,
+// so launching several shards against the same host concurrently reuses
+// both safely without extra locking here.
+func runSuiteOnHost(path, host string, deleteFiles bool, junitFileNum int, setupNode bool, extraTestArgs string) *TestResult {
+	start := time.Now()
+	testArgsForHost := *testArgs
+	if extraTestArgs != "" {
+		testArgsForHost = strings.TrimSpace(testArgsForHost + " " + extraTestArgs)
+	}
+	junitName := fmt.Sprintf("junit_%02d.xml", junitFileNum)
+
+	if *shardsPerHost <= 1 {
+		output, exitOk, err := e2e_node.RunRemote(path, host, deleteFiles, junitFileNum, setupNode, testArgsForHost)
 		return &TestResult{
-			err: fmt.Errorf("unable to create gce instance with running docker daemon for image %s.  %v", image, err),
+			output:    output,
+			err:       err,
+			host:      host,
+			exitOk:    exitOk,
+			duration:  time.Since(start),
+			junitPath: junitReportPath(junitName),
 		}
 	}
 
-	// Only delete the files if we are keeping the instance and want it cleaned up.
-	// If we are going to delete the instance, don't bother with cleaning up the files
-	deleteFiles := !*deleteInstances && *cleanup
-	return testHost(host, deleteFiles, junitFileNum, *setupNode)
-}
-
-// Provision a gce instance using image
-func createInstance(image, imageProject string) (string, error) {
-	name := imageToInstanceName(image)
-	i := &compute.Instance{
-		Name:        name,
-		MachineType: machineType(),
-		NetworkInterfaces: []*compute.NetworkInterface{
-			{
-				AccessConfigs: []*compute.AccessConfig{
-					{
-						Type: "ONE_TO_ONE_NAT",
-						Name: "External NAT",
-					},
-				}},
-		},
-		Disks: []*compute.AttachedDisk{
-			{
-				AutoDelete: true,
-				Boot:       true,
-				Type:       "PERSISTENT",
-				InitializeParams: &compute.AttachedDiskInitializeParams{
-					SourceImage: sourceImage(image, imageProject),
-				},
-			},
-		},
-	}
-	if *instanceMetadata != "" {
-		raw := parseInstanceMetadata(*instanceMetadata)
-		i.Metadata = &compute.Metadata{}
-		metadata := []*compute.MetadataItems{}
-		for k, v := range raw {
-			val := v
-			metadata = append(metadata, &compute.MetadataItems{
-				Key:   k,
-				Value: &val,
-			})
-		}
-		i.Metadata.Items = metadata
-	}
-	op, err := computeService.Instances.Insert(*project, *zone, i).Do()
-	if err != nil {
-		return "", err
-	}
-	if op.Error != nil {
-		return "", fmt.Errorf("could not create instance %s: %+v", name, op.Error)
+	type shardResult struct {
+		output       string
+		exitOk       bool
+		err          error
+		junitFileNum int
+	}
+	shardResults := make(chan shardResult, *shardsPerHost)
+	junitFileNumbers := make([]int, 0, *shardsPerHost)
+	for i := 0; i < *shardsPerHost; i++ {
+		shardJunitNum := junitFileNum*1000 + i
+		junitFileNumbers = append(junitFileNumbers, shardJunitNum)
+		go func(shardIndex, shardJunitNum int) {
+			focusSkip, err := shardTestArgs(path, shardIndex, *shardsPerHost)
+			if err != nil {
+				shardResults <- shardResult{err: err, junitFileNum: shardJunitNum}
+				return
+			}
+			output, exitOk, err := e2e_node.RunRemote(path, host, deleteFiles, shardJunitNum, setupNode, testArgsForHost+" "+focusSkip)
+			shardResults <- shardResult{output: output, exitOk: exitOk, err: err, junitFileNum: shardJunitNum}
+		}(i, shardJunitNum)
 	}
 
-	instanceRunning := false
-	for i := 0; i < 30 && !instanceRunning; i++ {
-		if i > 0 {
-			time.Sleep(time.Second * 20)
-		}
-		var instance *compute.Instance
-		instance, err = computeService.Instances.Get(*project, *zone, name).Do()
-		if err != nil {
-			continue
-		}
-		if strings.ToUpper(instance.Status) != "RUNNING" {
-			err = fmt.Errorf("instance %s not in state RUNNING, was %s.", name, instance.Status)
-			continue
-		}
-		externalIp := getExternalIp(instance)
-		if len(externalIp) > 0 {
-			e2e_node.AddHostnameIp(name, externalIp)
-		}
-		var output string
-		output, err = e2e_node.RunSshCommand("ssh", e2e_node.GetHostnameOrIp(name), "--", "sudo", "docker", "version")
-		if err != nil {
-			err = fmt.Errorf("instance %s not running docker daemon - Command failed: %s", name, output)
-			continue
-		}
-		if !strings.Contains(output, "Server") {
-			err = fmt.Errorf("instance %s not running docker daemon - Server not found: %s", name, output)
-			continue
+	var output bytes.Buffer
+	exitOk := true
+	var errs []string
+	for i := 0; i < *shardsPerHost; i++ {
+		r := <-shardResults
+		fmt.Fprintf(&output, "--- shard %d (junit_%02d.xml) ---\n%s\n", r.junitFileNum%1000, r.junitFileNum, r.output)
+		exitOk = exitOk && r.exitOk
+		if r.err != nil {
+			errs = append(errs, r.err.Error())
 		}
-		instanceRunning = true
 	}
-	return name, err
-}
 
-func getExternalIp(instance *compute.Instance) string {
-	for i := range instance.NetworkInterfaces {
-		ni := instance.NetworkInterfaces[i]
-		for j := range ni.AccessConfigs {
-			ac := ni.AccessConfigs[j]
-			if len(ac.NatIP) > 0 {
-				return ac.NatIP
-			}
+	if *reportDir != "" {
+		if err := mergeJUnitReports(*reportDir, junitName, junitFileNumbers); err != nil {
+			errs = append(errs, fmt.Sprintf("could not merge shard junit reports: %v", err))
 		}
 	}
-	return ""
-}
-
-func getComputeClient() (*compute.Service, error) {
-	const retries = 10
-	const backoff = time.Second * 6
 
-	// Setup the gce client for provisioning instances
-	// Getting credentials on gce jenkins is flaky, so try a couple times
 	var err error
-	var cs *compute.Service
-	for i := 0; i < retries; i++ {
-		if i > 0 {
-			time.Sleep(backoff)
-		}
-
-		var client *http.Client
-		client, err = google.DefaultClient(oauth2.NoContext, compute.ComputeScope)
-		if err != nil {
-			continue
-		}
+	if len(errs) > 0 {
+		err = fmt.Errorf(strings.Join(errs, "; "))
+	}
+	return &TestResult{
+		output:    output.String(),
+		err:       err,
+		host:      host,
+		exitOk:    exitOk,
+		duration:  time.Since(start),
+		junitPath: junitReportPath(junitName),
+	}
+}
 
-		cs, err = compute.New(client)
-		if err != nil {
-			continue
-		}
-		return cs, nil
+// junitReportPath returns the path a junit report was (or would be) written
+// to, or "" when --report-dir wasn't set.
+func junitReportPath(junitName string) string {
+	if *reportDir == "" {
+		return ""
 	}
-	return nil, err
+	return filepath.Join(*reportDir, junitName)
 }
 
-func deleteInstance(image string) {
-	_, err := computeService.Instances.Delete(*project, *zone, imageToInstanceName(image)).Do()
+// Provision an instance for image via its configured Provisioner and run the
+// tests in archive against the instance. Delete the instance afterward.
+func testImage(image Image, junitFileNum int) *TestResult {
+	provisioner, err := getProvisioner(image.Provider)
+	if err != nil {
+		return &TestResult{err: err, provider: image.Provider, region: image.Region}
+	}
+	runtime, err := getContainerRuntime(image.Runtime)
 	if err != nil {
-		glog.Infof("Error deleting instance %s", imageToInstanceName(image))
+		return &TestResult{err: err, provider: image.Provider, region: image.Region}
 	}
+
+	host, err := provisioner.Create(image)
+	if *deleteInstances {
+		defer provisioner.Delete(image)
+	}
+	if err != nil {
+		return &TestResult{
+			err:      fmt.Errorf("unable to create %s instance with running %s runtime for image %s.  %v", provisioner.Name(), runtime.Name(), image.Image, err),
+			provider: image.Provider,
+			region:   image.Region,
+		}
+	}
+
+	// Only delete the files if we are keeping the instance and want it cleaned up.
+	// If we are going to delete the instance, don't bother with cleaning up the files
+	deleteFiles := !*deleteInstances && *cleanup
+	result := testHost(image.Provider, host, deleteFiles, junitFileNum, *setupNode, runtime.KubeletFlags())
+	result.provider = image.Provider
+	result.region = image.Region
+	return result
 }
 
 func parseInstanceMetadata(str string) map[string]string {
@@ -434,15 +454,3 @@ func parseInstanceMetadata(str string) map[string]string {
 	}
 	return metadata
 }
-
-func imageToInstanceName(image string) string {
-	return *instanceNamePrefix + "-" + image
-}
-
-func sourceImage(image, imageProject string) string {
-	return fmt.Sprintf("projects/%s/global/images/%s", imageProject, image)
-}
-
-func machineType() string {
-	return fmt.Sprintf("zones/%s/machineTypes/n1-standard-1", *zone)
-}
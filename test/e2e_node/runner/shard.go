@@ -0,0 +1,178 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var shardsPerHost = flag.Int("shards-per-host", 1, "split the suite into this many concurrent shards per host, assigning each spec to a shard by hashing its name")
+
+// specNames is populated once, the first time it is needed, by dry-running
+// the test archive so every host shards the same way.
+var specNames struct {
+	sync.Once
+	names []string
+	err   error
+}
+
+// listSpecNames dry-runs the e2e_node.test binary inside the archive to
+// collect every spec's full text without executing it, so shards can be
+// computed identically on every host.
+func listSpecNames(archivePath string) ([]string, error) {
+	specNames.Do(func() {
+		dir, err := ioutil.TempDir("", "e2e-node-shard")
+		if err != nil {
+			specNames.err = fmt.Errorf("could not create temp dir for dry run: %v", err)
+			return
+		}
+		defer os.RemoveAll(dir)
+
+		if out, err := exec.Command("tar", "-xzf", archivePath, "-C", dir).CombinedOutput(); err != nil {
+			specNames.err = fmt.Errorf("could not extract archive for dry run: %v: %s", err, out)
+			return
+		}
+		out, err := exec.Command(filepath.Join(dir, "e2e_node.test"),
+			"--ginkgo.dryRun", "--ginkgo.noColor").CombinedOutput()
+		if err != nil {
+			specNames.err = fmt.Errorf("dry run of e2e_node.test failed: %v: %s", err, out)
+			return
+		}
+		specNames.names = parseGinkgoDryRunOutput(string(out))
+	})
+	return specNames.names, specNames.err
+}
+
+// parseGinkgoDryRunOutput pulls spec names out of ginkgo's --ginkgo.dryRun
+// output, which prints one "•" bullet line containing the spec's full text
+// per spec.
+func parseGinkgoDryRunOutput(out string) []string {
+	var names []string
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "•") {
+			names = append(names, strings.TrimSpace(strings.TrimPrefix(line, "•")))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// shardSpecs deterministically partitions specNames into numShards buckets
+// by hashing each spec's full text, so a given spec always lands in the same
+// shard regardless of which host or run is doing the hashing.
+func shardSpecs(specNames []string, numShards int) [][]string {
+	buckets := make([][]string, numShards)
+	for _, name := range specNames {
+		h := fnv.New32a()
+		h.Write([]byte(name))
+		idx := int(h.Sum32() % uint32(numShards))
+		buckets[idx] = append(buckets[idx], name)
+	}
+	return buckets
+}
+
+// focusRegexForShard builds a -ginkgo.focus regex that matches exactly the
+// specs assigned to one shard.
+func focusRegexForShard(specs []string) string {
+	if len(specs) == 0 {
+		// No specs hashed into this shard. An empty focus would run
+		// everything, so shardTestArgs turns this "" into -ginkgo.skip=.*
+		// instead.
+		return ""
+	}
+	escaped := make([]string, 0, len(specs))
+	for _, s := range specs {
+		escaped = append(escaped, regexp.QuoteMeta(s))
+	}
+	return "^(" + strings.Join(escaped, "|") + ")$"
+}
+
+// shardTestArgs returns the single -ginkgo.focus argument for shard index of
+// numShards shards, or -ginkgo.skip=.* when no spec hashed into that shard,
+// to append to the shard's --test_args.
+func shardTestArgs(archivePath string, index, numShards int) (string, error) {
+	names, err := listSpecNames(archivePath)
+	if err != nil {
+		return "", err
+	}
+	buckets := shardSpecs(names, numShards)
+	focus := focusRegexForShard(buckets[index])
+	if focus == "" {
+		return "-ginkgo.skip=.*", nil
+	}
+	return fmt.Sprintf("-ginkgo.focus=%s", focus), nil
+}
+
+// mergeJUnitReports combines every junit_*.xml file produced by the shards
+// of a single suite run into one junit report, so CI ingestion sees a single
+// file per host instead of one per shard.
+func mergeJUnitReports(reportDir, outputFile string, junitFileNumbers []int) error {
+	var merged struct {
+		XMLName xml.Name `xml:"testsuites"`
+		Suites  []struct {
+			Inner []byte `xml:",innerxml"`
+			Attrs []xml.Attr `xml:",any,attr"`
+		} `xml:"testsuite"`
+	}
+
+	for _, n := range junitFileNumbers {
+		path := filepath.Join(reportDir, fmt.Sprintf("junit_%02d.xml", n))
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("could not read shard junit report %s: %v", path, err)
+		}
+		var suite struct {
+			Inner []byte     `xml:",innerxml"`
+			Attrs []xml.Attr `xml:",any,attr"`
+		}
+		if err := xml.Unmarshal(data, &suite); err != nil {
+			return fmt.Errorf("could not parse shard junit report %s: %v", path, err)
+		}
+		merged.Suites = append(merged.Suites, suite)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<testsuites>\n")
+	for _, suite := range merged.Suites {
+		buf.WriteString("<testsuite")
+		for _, a := range suite.Attrs {
+			fmt.Fprintf(&buf, " %s=%q", a.Name.Local, a.Value)
+		}
+		buf.WriteString(">")
+		buf.Write(suite.Inner)
+		buf.WriteString("</testsuite>\n")
+	}
+	buf.WriteString("</testsuites>\n")
+
+	return ioutil.WriteFile(filepath.Join(reportDir, outputFile), buf.Bytes(), 0644)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestShardSpecsIsDeterministic(t *testing.T) {
+	names := []string{"spec a", "spec b", "spec c", "spec d", "spec e"}
+
+	first := shardSpecs(names, 3)
+	second := shardSpecs(names, 3)
+
+	for shard := range first {
+		if len(first[shard]) != len(second[shard]) {
+			t.Fatalf("shard %d size changed between runs: %v vs %v", shard, first[shard], second[shard])
+		}
+		for i, name := range first[shard] {
+			if second[shard][i] != name {
+				t.Fatalf("shard %d spec order changed between runs: %v vs %v", shard, first[shard], second[shard])
+			}
+		}
+	}
+}
+
+func TestShardSpecsCoversEverySpecExactlyOnce(t *testing.T) {
+	names := []string{"spec a", "spec b", "spec c", "spec d", "spec e", "spec f", "spec g"}
+
+	buckets := shardSpecs(names, 4)
+
+	seen := map[string]int{}
+	for _, bucket := range buckets {
+		for _, name := range bucket {
+			seen[name]++
+		}
+	}
+	if len(seen) != len(names) {
+		t.Fatalf("expected %d distinct specs across shards, got %d: %v", len(names), len(seen), seen)
+	}
+	for name, count := range seen {
+		if count != 1 {
+			t.Errorf("spec %q assigned to %d shards, want exactly 1", name, count)
+		}
+	}
+}
+
+func TestFocusRegexForShardMatchesOnlyItsOwnSpecs(t *testing.T) {
+	specs := []string{"[k8s.io] Foo should do a thing", "[k8s.io] Bar should do another"}
+
+	focus := focusRegexForShard(specs)
+	re := regexp.MustCompile(focus)
+
+	for _, s := range specs {
+		if !re.MatchString(s) {
+			t.Errorf("focus regex %q did not match its own spec %q", focus, s)
+		}
+	}
+	if re.MatchString("[k8s.io] Baz should not match") {
+		t.Errorf("focus regex %q unexpectedly matched a spec outside the shard", focus)
+	}
+}
+
+func TestFocusRegexForShardEmpty(t *testing.T) {
+	if focus := focusRegexForShard(nil); focus != "" {
+		t.Errorf("focusRegexForShard(nil) = %q, want empty string", focus)
+	}
+}
+
+func TestParseGinkgoDryRunOutput(t *testing.T) {
+	out := "Running Suite\n" +
+		"• [k8s.io] Foo should do a thing\n" +
+		"some other non-spec line\n" +
+		"• [k8s.io] Bar should do another\n"
+
+	names := parseGinkgoDryRunOutput(out)
+
+	want := []string{"[k8s.io] Bar should do another", "[k8s.io] Foo should do a thing"}
+	if len(names) != len(want) {
+		t.Fatalf("parseGinkgoDryRunOutput() = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("parseGinkgoDryRunOutput()[%d] = %q, want %q", i, names[i], want[i])
+		}
+	}
+}
+
+func TestMergeJUnitReports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "merge-junit-test")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	shard0 := `<?xml version="1.0"?><testsuite name="shard0" tests="1"><testcase name="a"></testcase></testsuite>`
+	shard1 := `<?xml version="1.0"?><testsuite name="shard1" tests="1"><testcase name="b"></testcase></testsuite>`
+	if err := ioutil.WriteFile(filepath.Join(dir, "junit_1000.xml"), []byte(shard0), 0644); err != nil {
+		t.Fatalf("could not write shard0 report: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "junit_1001.xml"), []byte(shard1), 0644); err != nil {
+		t.Fatalf("could not write shard1 report: %v", err)
+	}
+
+	if err := mergeJUnitReports(dir, "junit_00.xml", []int{1000, 1001}); err != nil {
+		t.Fatalf("mergeJUnitReports() error = %v", err)
+	}
+
+	merged, err := ioutil.ReadFile(filepath.Join(dir, "junit_00.xml"))
+	if err != nil {
+		t.Fatalf("could not read merged report: %v", err)
+	}
+	for _, want := range []string{`name="shard0"`, `name="shard1"`, "<testcase name=\"a\">", "<testcase name=\"b\">"} {
+		if !regexp.MustCompile(regexp.QuoteMeta(want)).Match(merged) {
+			t.Errorf("merged report missing %q; got:\n%s", want, merged)
+		}
+	}
+}